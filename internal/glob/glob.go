@@ -0,0 +1,76 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package glob
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Pattern is a compiled case-insensitive glob pattern, where * matches any sequence of characters and ? a single
+// character.
+type Pattern struct {
+	re *regexp.Regexp
+}
+
+// compiled caches every pattern compiled, patterns are matched against every host's resources.
+var compiled sync.Map
+
+// Compile compiles a pattern, a pattern can not be invalid since every other character is matched literally.
+func Compile(pattern string) Pattern {
+	if p, ok := compiled.Load(pattern); ok {
+		return p.(Pattern)
+	}
+	p := Pattern{
+		re: regexp.MustCompile(
+			fmt.Sprintf(
+				"(?i)^%s$",
+				strings.NewReplacer(
+					`\*`, ".*",
+					`\?`, ".",
+				).Replace(regexp.QuoteMeta(pattern)),
+			),
+		),
+	}
+	compiled.Store(
+		pattern,
+		p,
+	)
+	return p
+}
+
+// MatchString returns whether s, without leading and trailing white space, matches the pattern.
+func (p Pattern) MatchString(s string) bool {
+	return p.re.MatchString(strings.TrimSpace(s))
+}
+
+// Match returns whether s matches a pattern.
+func Match(pattern string, s string) bool {
+	return Compile(pattern).MatchString(s)
+}
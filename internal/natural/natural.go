@@ -0,0 +1,67 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package natural
+
+import (
+	"strconv"
+	"unicode"
+)
+
+// Less compares two strings, comparing runs of digits numerically (e.g. node2 sorts before node10).
+func Less(a, b string) bool {
+	ar, br := []rune(a), []rune(b)
+	i, j := 0, 0
+	for i < len(ar) && j < len(br) {
+		if unicode.IsDigit(ar[i]) && unicode.IsDigit(br[j]) {
+			si := i
+			for i < len(ar) && unicode.IsDigit(ar[i]) {
+				i++
+			}
+			sj := j
+			for j < len(br) && unicode.IsDigit(br[j]) {
+				j++
+			}
+			na, errA := strconv.ParseUint(string(ar[si:i]), 10, 64)
+			nb, errB := strconv.ParseUint(string(br[sj:j]), 10, 64)
+			if errA == nil && errB == nil && na != nb {
+				return na < nb
+			}
+			if errA != nil || errB != nil {
+				if da, db := string(ar[si:i]), string(br[sj:j]); da != db {
+					return da < db
+				}
+			}
+			continue
+		}
+		if ar[i] != br[j] {
+			return ar[i] < br[j]
+		}
+		i++
+		j++
+	}
+	return len(ar)-i < len(br)-j
+}
@@ -0,0 +1,109 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package query
+
+import (
+	"sort"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/natural"
+)
+
+// Sources of an Owned resource.
+const (
+	SystemSource  = "system"
+	ManagerSource = "manager"
+)
+
+// Owned is a resource of a system or manager, and which of the two it came from.
+type Owned[T any] struct {
+	Item   *T
+	Source string
+}
+
+// GetOwned returns the resources (e.g. VirtualMedia or LogServices) of the first system and of every manager, read
+// with the given accessors. gofish reads collections concurrently, so the resources of each owner are ordered by id.
+func GetOwned[T any](
+	c *gofish.APIClient,
+	system func(*redfish.ComputerSystem) ([]*T, error),
+	manager func(*redfish.Manager) ([]*T, error),
+	id func(*T) string,
+) ([]Owned[T], error) {
+	var owned []Owned[T]
+	add := func(items []*T, source string) {
+		sort.Slice(
+			items,
+			func(i, j int) bool {
+				return natural.Less(
+					id(items[i]),
+					id(items[j]),
+				)
+			},
+		)
+		for _, item := range items {
+			owned = append(
+				owned,
+				Owned[T]{
+					Item:   item,
+					Source: source,
+				},
+			)
+		}
+	}
+
+	systems, err := c.Service.Systems()
+	if err != nil {
+		return nil, err
+	}
+	if len(systems) > 0 {
+		items, err := system(systems[0])
+		if err != nil {
+			return nil, err
+		}
+		add(
+			items,
+			SystemSource,
+		)
+	}
+	managers, err := c.Service.Managers()
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range managers {
+		items, err := manager(m)
+		if err != nil {
+			return nil, err
+		}
+		add(
+			items,
+			ManagerSource,
+		)
+	}
+	return owned, nil
+}
@@ -37,6 +37,7 @@ import (
 func Async(fn func(host string) interface{}, hosts []string) map[string]any {
 
 	var wg sync.WaitGroup
+	var mu sync.Mutex
 
 	sliceLength := len(hosts)
 	wg.Add(sliceLength)
@@ -49,7 +50,10 @@ func Async(fn func(host string) interface{}, hosts []string) map[string]any {
 		go func(host string, args ...string) {
 
 			defer wg.Done()
-			sm[host] = fn(host)
+			result := fn(host)
+			mu.Lock()
+			sm[host] = result
+			mu.Unlock()
 		}(host)
 	}
 	wg.Wait()
@@ -0,0 +1,69 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package query
+
+import (
+	"encoding/json"
+
+	"github.com/stmcginnis/gofish/common"
+)
+
+// GetJSON decodes the raw JSON of a resource into v.
+func GetJSON(client common.Client, uri string, v interface{}) error {
+	resp, err := client.Get(uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// GetMembers decodes the raw JSON of each member of a collection.
+func GetMembers[T any](client common.Client, uri string) ([]T, error) {
+	collection, err := common.GetCollection(
+		client,
+		uri,
+	)
+	if err != nil {
+		return nil, err
+	}
+	members := make(
+		[]T,
+		len(collection.ItemLinks),
+	)
+	for i, link := range collection.ItemLinks {
+		err = GetJSON(
+			client,
+			link,
+			&members[i],
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return members, nil
+}
@@ -0,0 +1,138 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package serve
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Server serves a single file over HTTP until it is closed.
+type Server struct {
+	file     string
+	name     string
+	listener net.Listener
+	server   *http.Server
+}
+
+// Start serves a file over HTTP on every interface at the given port, 0 picks a free port.
+func Start(file string, port int) (*Server, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf(
+			"%s is a directory",
+			file,
+		)
+	}
+	listener, err := net.Listen(
+		"tcp",
+		net.JoinHostPort(
+			"",
+			strconv.Itoa(port),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		file:     file,
+		name:     filepath.Base(file),
+		listener: listener,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+s.name {
+			http.NotFound(
+				w,
+				r,
+			)
+			return
+		}
+		http.ServeFile(
+			w,
+			r,
+			s.file,
+		)
+	})
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+	return s, nil
+}
+
+// URL returns the URL of the file for a host (a BMC), at the local address that is routed to the host.
+func (s *Server) URL(host string) (string, error) {
+	name := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		name = h
+	}
+	conn, err := net.Dial(
+		"udp",
+		net.JoinHostPort(
+			name,
+			"443",
+		),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	local := conn.LocalAddr().(*net.UDPAddr).IP
+
+	_, port, err := net.SplitHostPort(s.listener.Addr().String())
+	if err != nil {
+		return "", err
+	}
+	u := url.URL{
+		Scheme: "http",
+		Host: net.JoinHostPort(
+			local.String(),
+			port,
+		),
+		Path: "/" + s.name,
+	}
+	return u.String(), nil
+}
+
+// Close stops serving the file.
+func (s *Server) Close() error {
+	return s.server.Close()
+}
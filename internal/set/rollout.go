@@ -0,0 +1,329 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package set
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Cray-HPE/gru/internal/natural"
+)
+
+// Rollout orders.
+const (
+	ListOrder    = "list"
+	NaturalOrder = "natural"
+	ChassisOrder = "chassis"
+)
+
+// AddFlags adds the rollout flags to a command that updates hosts.
+func AddFlags(c *cobra.Command) {
+	c.PersistentFlags().Int(
+		"batch-size",
+		0,
+		"Update hosts in batches of this size, 0 updates every host in a single batch",
+	)
+	c.PersistentFlags().Duration(
+		"batch-interval",
+		0,
+		"Time to wait after a batch completes before starting the next batch (e.g. 30s)",
+	)
+	c.PersistentFlags().Int(
+		"max-in-flight",
+		0,
+		"Maximum number of hosts updated concurrently, 0 is unlimited",
+	)
+	c.PersistentFlags().String(
+		"rollout-order",
+		ListOrder,
+		fmt.Sprintf(
+			"Order hosts are updated in: %s (as given), %s (natural sort), or %s (spread batches across the chassis given in the config file)",
+			ListOrder,
+			NaturalOrder,
+			ChassisOrder,
+		),
+	)
+	c.PersistentFlags().Float64(
+		"max-failure-rate",
+		100,
+		"Stop starting new hosts once more than this percentage of the updated hosts have failed, requires --batch-size or --max-in-flight",
+	)
+}
+
+// Skipped is the result for hosts that were not updated because the rollout stopped.
+type Skipped struct {
+	Error error `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// tally counts the hosts updated and failed so far in a rollout, against `--max-failure-rate`.
+type tally struct {
+	completed      int
+	failed         int
+	maxFailureRate float64
+}
+
+// exceeded returns the failure rate so far, and whether it exceeds `--max-failure-rate`.
+func (t *tally) exceeded() (float64, bool) {
+	if t.completed == 0 {
+		return 0, false
+	}
+	rate := 100 * float64(t.failed) / float64(t.completed)
+	return rate, rate > t.maxFailureRate
+}
+
+// skip records every host as Skipped because the failure rate exceeded `--max-failure-rate`.
+func (t *tally) skip(hosts []string, sm map[string]interface{}) {
+	rate, _ := t.exceeded()
+	for _, host := range hosts {
+		sm[host] = Skipped{
+			Error: fmt.Errorf(
+				"skipped; %.1f%% of hosts failed, exceeding --max-failure-rate %.1f%%",
+				rate,
+				t.maxFailureRate,
+			),
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Stopping; %.1f%% of hosts failed, skipping [%5d] hosts\n", rate, len(hosts))
+}
+
+// rollout calls fn against every host, in batches when `--batch-size` was given, and returns each host's result.
+// `--max-failure-rate` is checked before each batch, and before each host is started when `--max-in-flight` limits
+// how many hosts are updated at once; without either every host is started at once, so it is rejected.
+func rollout(fn func(host string) interface{}, hosts []string) map[string]any {
+	v := viper.GetViper()
+
+	batchSize := v.GetInt("batch-size")
+	if batchSize <= 0 || batchSize > len(hosts) {
+		batchSize = len(hosts)
+	}
+	t := &tally{
+		maxFailureRate: 100,
+	}
+	if v.IsSet("max-failure-rate") {
+		t.maxFailureRate = v.GetFloat64("max-failure-rate")
+		if t.maxFailureRate < 100 && v.GetInt("batch-size") <= 0 && v.GetInt("max-in-flight") <= 0 {
+			fmt.Fprintln(
+				os.Stderr,
+				"--max-failure-rate requires --batch-size or --max-in-flight",
+			)
+			os.Exit(1)
+		}
+	}
+
+	ordered, err := order(
+		hosts,
+		v.GetString("rollout-order"),
+	)
+	if err != nil {
+		fmt.Fprintln(
+			os.Stderr,
+			err,
+		)
+		os.Exit(1)
+	}
+
+	sm := make(map[string]interface{})
+	if len(ordered) == 0 {
+		return sm
+	}
+
+	if batchSize == len(ordered) {
+		fmt.Fprintf(os.Stderr, "Asynchronously updating [%5d] hosts ... \n", len(ordered))
+		if started := run(fn, ordered, sm, t); started < len(ordered) {
+			t.skip(ordered[started:], sm)
+		}
+		return sm
+	}
+
+	batches := (len(ordered) + batchSize - 1) / batchSize
+	for b := 0; b < batches; b++ {
+		batch := ordered[b*batchSize : min((b+1)*batchSize, len(ordered))]
+
+		if b > 0 {
+			if _, exceeded := t.exceeded(); exceeded {
+				t.skip(ordered[b*batchSize:], sm)
+				break
+			}
+			if interval := v.GetDuration("batch-interval"); interval > 0 {
+				time.Sleep(interval)
+			}
+		}
+
+		fmt.Fprintf(os.Stderr, "Batch [%d/%d]: asynchronously updating [%5d] hosts ... \n", b+1, batches, len(batch))
+		completed, failed := t.completed, t.failed
+		started := run(fn, batch, sm, t)
+		fmt.Fprintf(os.Stderr, "Batch [%d/%d]: %d succeeded, %d failed\n", b+1, batches, t.completed-completed-(t.failed-failed), t.failed-failed)
+		if started < len(batch) {
+			t.skip(ordered[b*batchSize+started:], sm)
+			break
+		}
+	}
+	return sm
+}
+
+// run calls fn asynchronously against each host, with at most `--max-in-flight` calls at a time, and stores each
+// result in sm. Once the failure rate exceeds `--max-failure-rate` no more hosts are started. Returns the number of
+// hosts started, the rest are left to the caller to skip.
+func run(fn func(host string) interface{}, hosts []string, sm map[string]interface{}, t *tally) int {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	limit := viper.GetInt("max-in-flight")
+	if limit <= 0 {
+		limit = len(hosts)
+	}
+	sem := make(chan struct{}, limit)
+
+	started := 0
+	for _, host := range hosts {
+		sem <- struct{}{}
+		mu.Lock()
+		_, exceeded := t.exceeded()
+		mu.Unlock()
+		if exceeded {
+			break
+		}
+		started++
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			result := fn(host)
+			mu.Lock()
+			sm[host] = result
+			t.completed++
+			if Failed(result) {
+				t.failed++
+			}
+			mu.Unlock()
+		}(host)
+	}
+	wg.Wait()
+	return started
+}
+
+// Failed reports whether a result failed; the result is a struct with a non-nil Error field.
+func Failed(result interface{}) bool {
+	v := reflect.ValueOf(result)
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	f := v.FieldByName("Error")
+	return f.IsValid() && f.Kind() == reflect.Interface && !f.IsNil()
+}
+
+// order returns the hosts in the given rollout order.
+func order(hosts []string, by string) ([]string, error) {
+	ordered := make(
+		[]string,
+		len(hosts),
+	)
+	copy(
+		ordered,
+		hosts,
+	)
+
+	switch by {
+	case ListOrder, "":
+	case NaturalOrder:
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return natural.Less(ordered[i], ordered[j])
+		})
+	case ChassisOrder:
+		ordered = interleaveChassis(ordered)
+	default:
+		return nil, fmt.Errorf(
+			"invalid rollout order: %s",
+			by,
+		)
+	}
+	return ordered, nil
+}
+
+// interleaveChassis groups hosts by the `chassis` key of their entry in the config file's `hosts`, and interleaves
+// the groups so consecutive hosts (and therefore each batch) are spread across chassis. Hosts without a chassis are
+// grouped together. Within a group hosts are naturally sorted.
+func interleaveChassis(hosts []string) []string {
+	config := viper.GetStringMap("hosts")
+
+	var names []string
+	groups := make(map[string][]string)
+	for _, host := range hosts {
+		chassis := ""
+		if hostConfig, ok := config[host].(map[string]interface{}); ok {
+			if c, ok := hostConfig["chassis"]; ok {
+				chassis = fmt.Sprintf(
+					"%v",
+					c,
+				)
+			}
+		}
+		if _, ok := groups[chassis]; !ok {
+			names = append(
+				names,
+				chassis,
+			)
+		}
+		groups[chassis] = append(
+			groups[chassis],
+			host,
+		)
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return natural.Less(names[i], names[j])
+	})
+	for _, name := range names {
+		group := groups[name]
+		sort.SliceStable(group, func(i, j int) bool {
+			return natural.Less(group[i], group[j])
+		})
+	}
+
+	ordered := make(
+		[]string,
+		0,
+		len(hosts),
+	)
+	for i := 0; len(ordered) < len(hosts); i++ {
+		for _, name := range names {
+			if i < len(groups[name]) {
+				ordered = append(
+					ordered,
+					groups[name][i],
+				)
+			}
+		}
+	}
+	return ordered
+}
@@ -26,41 +26,21 @@
 
 package set
 
-import (
-	"fmt"
-	"os"
-	"sync"
-)
-
 // Async calls the given function (fn) asynchronously against each element in a given slice of HTTP/S endpoints. This
 // functional also takes a data argument, which is passed along to each async function. Each async call must return an
 // interface. Finally, this function returns a map for each result for each endpoint's function call.
+// The endpoints are updated according to the rollout flags (see AddFlags).
 func Async(
 	fn func(host string, action any) interface{},
 	hosts []string,
 	data any,
 ) map[string]any {
-
-	var wg sync.WaitGroup
-
-	sliceLength := len(hosts)
-	wg.Add(sliceLength)
-
-	fmt.Fprintf(os.Stderr, "Asynchronously updating [%5d] hosts ... \n", len(hosts))
-
-	sm := make(map[string]interface{})
-
-	for _, host := range hosts {
-
-		go func(host string) {
-
-			defer wg.Done()
-			sm[host] = fn(host, data)
-
-		}(host)
-	}
-	wg.Wait()
-	return sm
+	return rollout(
+		func(host string) interface{} {
+			return fn(host, data)
+		},
+		hosts,
+	)
 }
 
 // AsyncMap is exactly like Async, however the async function is expected to return a map of interfaces.
@@ -70,27 +50,12 @@ func AsyncMap(
 	hosts []string,
 	data map[string]interface{},
 ) map[string]any {
-
-	var wg sync.WaitGroup
-
-	sliceLength := len(hosts)
-	wg.Add(sliceLength)
-
-	fmt.Fprintf(os.Stderr, "Asynchronously updating [%5d] hosts ... \n", len(hosts))
-
-	sm := make(map[string]interface{})
-
-	for _, host := range hosts {
-
-		go func(host string) {
-
-			defer wg.Done()
-			sm[host] = fn(host, data)
-
-		}(host)
-	}
-	wg.Wait()
-	return sm
+	return rollout(
+		func(host string) interface{} {
+			return fn(host, data)
+		},
+		hosts,
+	)
 }
 
 // AsyncCall is exactly like Async, however the async function call does not require any arguments be passed to it.
@@ -99,25 +64,8 @@ func AsyncCall(
 	fn func(host string) interface{},
 	hosts []string,
 ) map[string]any {
-
-	var wg sync.WaitGroup
-
-	sliceLength := len(hosts)
-	wg.Add(sliceLength)
-
-	fmt.Fprintf(os.Stderr, "Asynchronously updating [%5d] hosts ... \n", len(hosts))
-
-	sm := make(map[string]interface{})
-
-	for _, host := range hosts {
-
-		go func(host string) {
-
-			defer wg.Done()
-			sm[host] = fn(host)
-
-		}(host)
-	}
-	wg.Wait()
-	return sm
+	return rollout(
+		fn,
+		hosts,
+	)
 }
@@ -0,0 +1,222 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/wait"
+)
+
+// Status represents the last known status of a task, or of a job of the JobService.
+type Status struct {
+	URI             string
+	ID              string
+	Name            string
+	State           redfish.TaskState
+	Health          string
+	PercentComplete int
+	StartTime       string
+	EndTime         string
+	Messages        []string
+}
+
+// Location returns the task (monitor) of an accepted (202) request, or an empty string if the request completed
+// without a task.
+func Location(resp *http.Response) string {
+	if resp.StatusCode != http.StatusAccepted {
+		return ""
+	}
+	location := resp.Header.Get("Location")
+	if location == "" {
+		var t struct {
+			ODataID string `json:"@odata.id"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&t) == nil {
+			location = t.ODataID
+		}
+	}
+	return Path(location)
+}
+
+// Path returns the path (and query) of a URI, services may return absolute URIs for tasks and resources.
+func Path(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Path == "" {
+		return uri
+	}
+	if u.RawQuery != "" {
+		return u.Path + "?" + u.RawQuery
+	}
+	return u.Path
+}
+
+// Done returns whether a task state is final.
+func Done(state redfish.TaskState) bool {
+	switch state {
+	case redfish.CompletedTaskState, redfish.ExceptionTaskState, redfish.KilledTaskState, redfish.CancelledTaskState:
+		return true
+	}
+	return false
+}
+
+// Get returns the status of a task or task monitor. A task monitor that no longer answers 202 Accepted, without a
+// TaskState, has completed.
+func Get(client common.Client, uri string) (Status, error) {
+	status := Status{
+		URI: uri,
+	}
+	resp, err := client.Get(uri)
+	if err != nil {
+		return status, err
+	}
+	defer resp.Body.Close()
+
+	var t struct {
+		ID              string `json:"Id"`
+		Name            string
+		TaskState       redfish.TaskState
+		JobState        redfish.TaskState
+		TaskStatus      string
+		JobStatus       string
+		PercentComplete int
+		StartTime       string
+		EndTime         string
+		Messages        []struct {
+			Message string
+		}
+	}
+	// Task monitors may answer with an empty body, or the result of the operation.
+	_ = json.NewDecoder(resp.Body).Decode(&t)
+
+	status.ID = t.ID
+	status.Name = t.Name
+	status.State = t.TaskState
+	status.Health = t.TaskStatus
+	// A job's states are a superset of a task's states.
+	if status.State == "" {
+		status.State = t.JobState
+		status.Health = t.JobStatus
+	}
+	status.PercentComplete = t.PercentComplete
+	status.StartTime = t.StartTime
+	status.EndTime = t.EndTime
+	for _, m := range t.Messages {
+		if m.Message != "" {
+			status.Messages = append(
+				status.Messages,
+				m.Message,
+			)
+		}
+	}
+	if status.State == "" {
+		status.State = redfish.RunningTaskState
+		if resp.StatusCode != http.StatusAccepted {
+			status.State = redfish.CompletedTaskState
+		}
+	}
+	return status, nil
+}
+
+// Follow waits for the task of an accepted (202) request, if any, see Watch. Returns the task, or an empty string if
+// the request completed without a task.
+func Follow(client common.Client, resp *http.Response, timeout time.Duration, progress func(Status)) (string, error) {
+	location := Location(resp)
+	if location == "" {
+		return "", nil
+	}
+	_, err := Watch(
+		client,
+		location,
+		timeout,
+		progress,
+	)
+	return location, err
+}
+
+// Wait polls a task or task monitor until it is done, or until the timeout. Returns an error if the task did not
+// complete successfully.
+func Wait(client common.Client, uri string, timeout time.Duration) (Status, error) {
+	return Watch(
+		client,
+		uri,
+		timeout,
+		nil,
+	)
+}
+
+// Watch is like Wait, calling progress (if not nil) whenever the task's state or percent complete changes.
+func Watch(client common.Client, uri string, timeout time.Duration, progress func(Status)) (Status, error) {
+	deadline := time.Now().Add(timeout)
+	var last Status
+	for {
+		status, err := Get(
+			client,
+			uri,
+		)
+		if err != nil {
+			return status, err
+		}
+		if progress != nil && (status.State != last.State || status.PercentComplete != last.PercentComplete) {
+			progress(status)
+		}
+		last = status
+		if Done(status.State) {
+			if status.State != redfish.CompletedTaskState {
+				return status, fmt.Errorf(
+					"task %s: %s",
+					status.State,
+					strings.Join(
+						status.Messages,
+						"; ",
+					),
+				)
+			}
+			return status, nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return status, fmt.Errorf(
+				"timed out after %s waiting for task %s (%s, %d%%)",
+				timeout,
+				uri,
+				status.State,
+				status.PercentComplete,
+			)
+		}
+		time.Sleep(min(wait.PollInterval, remaining))
+	}
+}
@@ -0,0 +1,103 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package wait
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Cray-HPE/gru/internal/set"
+)
+
+// PollInterval is how often a host is polled while waiting for it.
+var PollInterval = 5 * time.Second
+
+// DefaultTimeout is how long `--wait` waits for each host unless `--timeout` is given.
+const DefaultTimeout = 5 * time.Minute
+
+// AddFlags adds the `--wait` and `--timeout` flags to a command, what describes what is waited for (e.g. "the
+// expected power state to be reached").
+func AddFlags(c *cobra.Command, what string) {
+	c.PersistentFlags().Bool(
+		"wait",
+		false,
+		fmt.Sprintf(
+			"Wait for %s",
+			what,
+		),
+	)
+	c.PersistentFlags().Duration(
+		"timeout",
+		DefaultTimeout,
+		"Maximum time to wait for each host with --wait",
+	)
+}
+
+// Timeout returns how long to wait for each host, or zero if `--wait` was not given.
+func Timeout() time.Duration {
+	if !viper.GetBool("wait") {
+		return 0
+	}
+	return viper.GetDuration("timeout")
+}
+
+// Check returns an error naming every host whose result has an error if `--wait` was given, so that the command
+// exits non-zero. noun and outcome describe the hosts that failed, e.g. "BMC" and "did not answer again".
+func Check(content map[string]interface{}, noun string, outcome string) error {
+	if Timeout() <= 0 {
+		return nil
+	}
+	var failed []string
+	for host, result := range content {
+		if set.Failed(result) {
+			failed = append(
+				failed,
+				host,
+			)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	sort.Strings(failed)
+	return fmt.Errorf(
+		"%d of %d %s(s) %s: %s",
+		len(failed),
+		len(content),
+		noun,
+		outcome,
+		strings.Join(
+			failed,
+			" ",
+		),
+	)
+}
@@ -94,14 +94,18 @@ func Connection(host string) (*gofish.APIClient, error) {
 	password := viper.GetString("password")
 	if val, ok := hosts[host]; ok {
 		hostConfig := val.(map[string]interface{})
-		username = fmt.Sprintf(
-			"%v",
-			hostConfig["username"],
-		)
-		password = fmt.Sprintf(
-			"%v",
-			hostConfig["password"],
-		)
+		if u, ok := hostConfig["username"]; ok {
+			username = fmt.Sprintf(
+				"%v",
+				u,
+			)
+		}
+		if p, ok := hostConfig["password"]; ok {
+			password = fmt.Sprintf(
+				"%v",
+				p,
+			)
+		}
 	}
 	config := gofish.ClientConfig{
 		Endpoint: "https://" + host,
@@ -41,10 +41,12 @@ import (
 	"github.com/Cray-HPE/gru/pkg/cmd/cli/bios/collections"
 )
 
-// Settings is a structure for holding current BIOS attributes, pending attributes, and errors.
+// Settings is a structure for holding current BIOS attributes, pending attributes, the task of a reset (if any), and
+// errors.
 type Settings struct {
 	Attributes map[string]interface{} `json:"attributes,omitempty" yaml:"attributes,omitempty"`
 	Pending    map[string]interface{} `json:"pending,omitempty" yaml:"pending,omitempty"`
+	Task       string                 `json:"task,omitempty" yaml:"task,omitempty"`
 	Error      error                  `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
@@ -92,6 +94,7 @@ func NewCommand() *cobra.Command {
 
 	c.AddCommand(
 		NewBiosGetCommand(),
+		NewBiosPasswordCommand(),
 		NewBiosSetCommand(),
 	)
 	return c
@@ -176,15 +179,21 @@ func getSystemBios(host string) (systems []*redfish.ComputerSystem, bios *redfis
 
 	service := c.Service
 	systems, err = service.Systems()
-	if err != nil || len(systems) < 1 {
+	if err != nil {
 		return systems, bios, err
 	}
+	if len(systems) < 1 {
+		return systems, bios, fmt.Errorf("no systems found")
+	}
 
 	// TODO from above: create map[string]*redfish.Bios (systems[0].HostName)
 	bios, err = systems[0].Bios()
 	if err != nil {
 		return systems, bios, err
 	}
+	if bios == nil {
+		return systems, bios, fmt.Errorf("no BIOS found")
+	}
 
 	return systems, bios, nil
 }
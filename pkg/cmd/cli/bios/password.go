@@ -0,0 +1,184 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package bios
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// PasswordChange represents the result of changing a BIOS password, the passwords themselves are never included.
+type PasswordChange struct {
+	Name  string `json:"name" yaml:"name"`
+	Error error  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// passwords holds the old and new values for a named BIOS password.
+type passwords struct {
+	name        string
+	oldPassword string
+	newPassword string
+}
+
+// NewBiosPasswordCommand creates the `password` subcommand for `bios`.
+func NewBiosPasswordCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "password",
+		Short: "BIOS password management",
+		Long:  `Manage the BIOS passwords (e.g. the setup/administrator password)`,
+	}
+	c.AddCommand(
+		NewBiosPasswordSetCommand(),
+	)
+	return c
+}
+
+// NewBiosPasswordSetCommand creates the `set` subcommand for `bios password`.
+func NewBiosPasswordSetCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "set host [...host]",
+		Short: "Changes a BIOS password",
+		Long: `Changes a BIOS password with the Bios.ChangePassword action.
+
+Passwords are never accepted as arguments, they are read from (in order of precedence):
+  - stdin, the old password on the first line and the new password on the second line (--password-stdin)
+  - the config file keys or environment variables bios_old_password/BIOS_OLD_PASSWORD and bios_new_password/BIOS_NEW_PASSWORD
+  - an interactive prompt
+
+When passwords are read from stdin the hosts must be given as arguments.`,
+		Run: func(c *cobra.Command, args []string) {
+			var hosts []string
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			p := passwords{name: v.GetString("name")}
+
+			if v.GetBool("password-stdin") {
+				if len(args) < 1 {
+					cmd.CheckError(fmt.Errorf("no hosts given, hosts must be given as arguments with --password-stdin"))
+				}
+				lines, err := cli.ReadStdinLines(2)
+				cmd.CheckError(err)
+				p.oldPassword = lines[0]
+				p.newPassword = lines[1]
+				hosts = args
+			} else {
+				hosts = cli.ParseHosts(args)
+				p.oldPassword = v.GetString("bios_old_password")
+				p.newPassword = v.GetString("bios_new_password")
+				if p.oldPassword == "" || p.newPassword == "" {
+					err := promptPasswords(&p)
+					cmd.CheckError(err)
+				}
+			}
+
+			content := set.Async(
+				changePassword,
+				hosts,
+				p,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	c.PersistentFlags().String(
+		"name",
+		"AdminPassword",
+		"Name of the BIOS password to change (e.g. AdminPassword, UserPassword)",
+	)
+	c.PersistentFlags().Bool(
+		"password-stdin",
+		false,
+		"Read the old and new passwords from stdin (one per line)",
+	)
+	set.AddFlags(c)
+	return c
+}
+
+// promptPasswords interactively prompts for the old and new password, the new password must be entered twice.
+func promptPasswords(p *passwords) (err error) {
+	p.oldPassword, err = cli.ReadSecret(
+		fmt.Sprintf(
+			"Current %s: ",
+			p.name,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	p.newPassword, err = cli.ReadSecret(
+		fmt.Sprintf(
+			"New %s: ",
+			p.name,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	confirm, err := cli.ReadSecret(
+		fmt.Sprintf(
+			"Confirm new %s: ",
+			p.name,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	if confirm != p.newPassword {
+		return fmt.Errorf("passwords do not match")
+	}
+	return nil
+}
+
+// changePassword changes a BIOS password on a host.
+func changePassword(host string, data interface{}) interface{} {
+	p := data.(passwords)
+	change := PasswordChange{Name: p.name}
+
+	_, bios, err := getSystemBios(host)
+	if err != nil {
+		change.Error = err
+		return change
+	}
+
+	err = bios.ChangePassword(
+		p.name,
+		p.oldPassword,
+		p.newPassword,
+	)
+	if err != nil {
+		change.Error = err
+	}
+	return change
+}
@@ -27,12 +27,16 @@ package bios
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stmcginnis/gofish/redfish"
 
+	"github.com/Cray-HPE/gru/internal/query"
 	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/internal/task"
+	"github.com/Cray-HPE/gru/pkg/auth"
 	"github.com/Cray-HPE/gru/pkg/cmd/cli"
 	"github.com/Cray-HPE/gru/pkg/cmd/cli/bios/collections"
 )
@@ -100,7 +104,13 @@ func NewBiosSetCommand() *cobra.Command {
 		false,
 		"Clear CMOS; set all BIOS attributes to their defaults.",
 	)
+	c.PersistentFlags().Duration(
+		"timeout",
+		10*time.Minute,
+		"Maximum time to wait for the task of a --clear-cmos, if the BMC returns one",
+	)
 
+	set.AddFlags(c)
 	return c
 }
 
@@ -170,13 +180,61 @@ func setBios(host string, requestedAttributes map[string]interface{}) interface{
 func resetBios(host string) interface{} {
 	attributes := Settings{}
 
-	_, bios, err := getSystemBios(host)
+	c, err := auth.Connection(host)
 	if err != nil {
 		attributes.Error = err
 		return attributes
 	}
+	defer c.Logout()
 
-	err = bios.ResetBios()
+	systems, err := c.Service.Systems()
+	if err != nil || len(systems) < 1 {
+		attributes.Error = err
+		return attributes
+	}
+	bios, err := systems[0].Bios()
+	if err != nil {
+		attributes.Error = err
+		return attributes
+	}
+
+	// The action is posted directly, rather than with bios.ResetBios, to follow its task.
+	var raw struct {
+		Actions struct {
+			ResetBios struct {
+				Target string
+			} `json:"#Bios.ResetBios"`
+		}
+	}
+	err = query.GetJSON(
+		c,
+		bios.ODataID,
+		&raw,
+	)
+	if err != nil {
+		attributes.Error = err
+		return attributes
+	}
+	target := raw.Actions.ResetBios.Target
+	if target == "" {
+		target = bios.ODataID + "/Actions/Bios.ResetBios"
+	}
+	resp, err := c.Post(
+		target,
+		struct{}{},
+	)
+	if err != nil {
+		attributes.Error = err
+		return attributes
+	}
+	defer resp.Body.Close()
+
+	attributes.Task, err = task.Follow(
+		c,
+		resp,
+		viper.GetDuration("timeout"),
+		nil,
+	)
 	if err != nil {
 		attributes.Error = err
 		return attributes
@@ -0,0 +1,291 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package bmc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/internal/wait"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// Reset represents a reset of a BMC. Elapsed is only set when waiting for the BMC to answer again.
+type Reset struct {
+	Manager         string                      `json:"manager,omitempty" yaml:"manager,omitempty"`
+	ResetType       redfish.ResetType           `json:"resetType,omitempty" yaml:"reset_type,omitempty"`
+	ResetToDefaults redfish.ResetToDefaultsType `json:"resetToDefaults,omitempty" yaml:"reset_to_defaults,omitempty"`
+	Elapsed         string                      `json:"elapsed,omitempty" yaml:"elapsed,omitempty"`
+	Error           error                       `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+var resetToDefaultsTypes = []redfish.ResetToDefaultsType{
+	redfish.ResetAllResetToDefaultsType,
+	redfish.PreserveNetworkAndUsersResetToDefaultsType,
+	redfish.PreserveNetworkResetToDefaultsType,
+}
+
+// NewResetCommand creates the `reset` subcommand for `bmc`.
+func NewResetCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "reset host [...host]",
+		Short: "Reboot the BMC(s)",
+		Long: `Reboots the BMC(s) of the target machine(s) with a GracefulRestart, or a ForceRestart when forced.
+The host itself is not reset.
+
+--to-defaults resets the BMC's settings to factory defaults instead; ResetAll may also reset the
+BMC's network settings and credentials, leaving it unreachable.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			reset := Reset{
+				ResetType: redfish.GracefulRestartResetType,
+			}
+			if v.GetBool("force") {
+				reset.ResetType = redfish.ForceRestartResetType
+			}
+			if toDefaults := v.GetString("to-defaults"); toDefaults != "" {
+				reset.ResetType = ""
+				reset.ResetToDefaults = redfish.ResetToDefaultsType(toDefaults)
+				valid := false
+				for _, t := range resetToDefaultsTypes {
+					if t == reset.ResetToDefaults {
+						valid = true
+					}
+				}
+				if !valid {
+					cmd.CheckError(
+						fmt.Errorf(
+							"invalid --to-defaults: %s, expected one of %v",
+							toDefaults,
+							resetToDefaultsTypes,
+						),
+					)
+				}
+			}
+
+			content := set.Async(
+				resetManager,
+				hosts,
+				reset,
+			)
+			cli.PrettyPrint(content)
+			cmd.CheckError(
+				wait.Check(
+					content,
+					"BMC",
+					"did not answer again",
+				),
+			)
+		},
+	}
+	c.PersistentFlags().BoolP(
+		"force",
+		"f",
+		false,
+		"Issue a ForceRestart instead of a GracefulRestart",
+	)
+	c.PersistentFlags().String(
+		"to-defaults",
+		"",
+		fmt.Sprintf(
+			"Reset the BMC's settings to factory defaults instead of rebooting it: %v",
+			resetToDefaultsTypes,
+		),
+	)
+	c.MarkFlagsMutuallyExclusive(
+		"force",
+		"to-defaults",
+	)
+	wait.AddFlags(
+		c,
+		"the BMC's Redfish service to answer again",
+	)
+	set.AddFlags(c)
+	return c
+}
+
+// resetManager resets a host's (first) manager. If `--wait` was given, resetManager waits for the BMC to restart and
+// its Redfish service to answer again.
+func resetManager(host string, data any) interface{} {
+	reset := data.(Reset)
+	c, err := auth.Connection(host)
+	if err != nil {
+		reset.Error = err
+		return reset
+	}
+	defer c.Logout()
+
+	managers, err := c.Service.Managers()
+	if err != nil {
+		reset.Error = err
+		return reset
+	}
+	if len(managers) < 1 {
+		reset.Error = fmt.Errorf("no managers found")
+		return reset
+	}
+	manager := managers[0]
+	reset.Manager = manager.ID
+	lastReset := manager.LastResetTime
+
+	start := time.Now()
+	if reset.ResetToDefaults != "" {
+		err = checkResetToDefaults(
+			manager,
+			reset.ResetToDefaults,
+		)
+		if err == nil {
+			err = manager.ResetToDefaults(reset.ResetToDefaults)
+		}
+	} else {
+		err = manager.Reset(reset.ResetType)
+	}
+	if err != nil {
+		reset.Error = err
+		return reset
+	}
+
+	if timeout := wait.Timeout(); timeout > 0 {
+		reset.Error = waitForService(
+			host,
+			lastReset,
+			timeout,
+		)
+		reset.Elapsed = time.Since(start).Round(time.Second).String()
+	}
+	return reset
+}
+
+// checkResetToDefaults returns an error if a manager does not support the ResetToDefaults action, or the given type.
+func checkResetToDefaults(manager *redfish.Manager, resetType redfish.ResetToDefaultsType) error {
+	resp, err := manager.GetClient().Get(manager.ODataID)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var raw struct {
+		Actions map[string]struct {
+			Target          string   `json:"target"`
+			AllowableValues []string `json:"ResetType@Redfish.AllowableValues"`
+		}
+	}
+	err = json.NewDecoder(resp.Body).Decode(&raw)
+	if err != nil {
+		return err
+	}
+
+	action, ok := raw.Actions["#Manager.ResetToDefaults"]
+	if !ok || action.Target == "" {
+		return fmt.Errorf("ResetToDefaults is not supported by this manager")
+	}
+	if len(action.AllowableValues) == 0 {
+		return nil
+	}
+	for _, allowed := range action.AllowableValues {
+		if allowed == string(resetType) {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"%s is not supported by this manager, the manager allows: %s",
+		resetType,
+		strings.Join(
+			action.AllowableValues,
+			", ",
+		),
+	)
+}
+
+// waitForService polls a host until its BMC has restarted and its Redfish service answers again. The BMC counts as
+// restarted once it has stopped answering, or its LastResetTime has changed.
+func waitForService(host string, lastReset string, timeout time.Duration) error {
+	restarted := false
+	deadline := time.Now().Add(timeout)
+
+	for {
+		interval := wait.PollInterval
+		if remaining := time.Until(deadline); remaining < interval {
+			interval = remaining
+		}
+		if interval > 0 {
+			time.Sleep(interval)
+		}
+
+		resetTime, err := lastResetTime(host)
+		if err != nil {
+			restarted = true
+		} else if restarted || (resetTime != "" && resetTime != lastReset) {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if !restarted {
+				return fmt.Errorf(
+					"timed out after %s waiting for the BMC to restart",
+					timeout,
+				)
+			}
+			return fmt.Errorf(
+				"timed out after %s waiting for the BMC's Redfish service to answer",
+				timeout,
+			)
+		}
+	}
+}
+
+// lastResetTime connects to a host and returns its (first) manager's LastResetTime.
+func lastResetTime(host string) (string, error) {
+	c, err := auth.Connection(host)
+	if err != nil {
+		return "", err
+	}
+	defer c.Logout()
+
+	managers, err := c.Service.Managers()
+	if err != nil {
+		return "", err
+	}
+	if len(managers) < 1 {
+		return "", fmt.Errorf("no managers found")
+	}
+	return managers[0].LastResetTime, nil
+}
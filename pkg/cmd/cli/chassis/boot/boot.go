@@ -27,13 +27,18 @@
 package boot
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stmcginnis/gofish/redfish"
 
-	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/chassis/power"
 )
 
 // Boot represents boot configuration on the BMC. Only Error is emitted on empty.
@@ -45,24 +50,34 @@ type Boot struct {
 
 // Override represents the result of the boot override.
 type Override struct {
-	Target redfish.BootSourceOverrideTarget `json:"target" yaml:"target"`
-	Error  error                            `json:"error,omitempty" yaml:"error,omitempty"`
+	Target      redfish.BootSourceOverrideTarget `json:"target" yaml:"target"`
+	Mode        redfish.BootSourceOverrideMode   `json:"mode,omitempty" yaml:"mode,omitempty"`
+	BootNext    string                           `json:"bootNext,omitempty" yaml:"boot_next,omitempty"`
+	UefiTarget  string                           `json:"uefiTarget,omitempty" yaml:"uefi_target,omitempty"`
+	HTTPBootURI string                           `json:"httpBootUri,omitempty" yaml:"http_boot_uri,omitempty"`
+	Error       error                            `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
 // NewCommand creates the `boot` subcommand for `chassis`.
 func NewCommand() *cobra.Command {
 	c := &cobra.Command{
-		Use:    "boot",
-		Short:  "Set next boot device",
-		Long:   `Overrides the next boot device for a one-time override. Only sets UEFI boot modes`,
+		Use:   "boot",
+		Short: "Set next boot device",
+		Long: `Overrides the next boot device for a one-time override.
+
+The boot mode defaults to UEFI, --mode legacy selects Legacy (BIOS) boot and --mode auto keeps the system's
+current boot mode. The mode is checked against the modes the BMC allows.`,
 		Hidden: false,
 	}
 	c.AddCommand(
 		NewBiosOverrideCommand(),
+		NewCdOverrideCommand(),
 		NewHddOverrideCommand(),
 		NewPxeOverrideCommand(),
 		NewUEFIHttpOverrideCommand(),
+		NewNextCommand(),
 		NewNoneOverrideCommand(),
+		NewOrderCommand(),
 	)
 
 	c.PersistentFlags().BoolP(
@@ -83,33 +98,65 @@ func NewCommand() *cobra.Command {
 		),
 	)
 
+	c.PersistentFlags().StringP(
+		"mode",
+		"m",
+		"uefi",
+		"Boot mode for the override: uefi, legacy, or auto (keep the current boot mode)",
+	)
+
+	c.PersistentFlags().String(
+		"reset-type",
+		string(redfish.ForceRestartResetType),
+		fmt.Sprintf(
+			"ResetType used by --now (e.g. %s, %s, or %s)",
+			redfish.ForceRestartResetType,
+			redfish.GracefulRestartResetType,
+			redfish.PowerCycleResetType,
+		),
+	)
+
 	return c
 }
 
 // issueOverride issues a boot override action against a host.
 func issueOverride(host string, override interface{}) interface{} {
-	o := Override{}
-	v := viper.GetViper()
+	o := Override{Target: override.(redfish.BootSourceOverrideTarget)}
 
-	c, err := auth.Connection(host)
+	system, logout, err := getSystem(host)
 	if err != nil {
 		o.Error = err
 		return o
 	}
+	defer logout()
 
-	defer c.Logout()
-
-	service := c.Service
-
-	systems, err := service.Systems()
-	if err != nil || len(systems) < 1 {
+	o.Mode, err = applyOverride(
+		system,
+		redfish.Boot{
+			BootSourceOverrideTarget: o.Target,
+		},
+	)
+	if err != nil {
 		o.Error = err
-		return o
 	}
 
-	boot := redfish.Boot{
-		BootSourceOverrideTarget: override.(redfish.BootSourceOverrideTarget),
-		BootSourceOverrideMode:   redfish.UEFIBootSourceOverrideMode,
+	return o
+}
+
+// applyOverride applies a boot override to a system in the boot mode given by `--mode`, the override is onetime
+// unless `--persist` was given. Returns the boot mode of the override.
+func applyOverride(system *redfish.ComputerSystem, boot redfish.Boot) (redfish.BootSourceOverrideMode, error) {
+	v := viper.GetViper()
+
+	mode, err := overrideMode(
+		system,
+		v.GetString("mode"),
+	)
+	if err != nil {
+		return mode, err
+	}
+	if !strings.EqualFold(v.GetString("mode"), "auto") {
+		boot.BootSourceOverrideMode = mode
 	}
 
 	if v.GetBool("persist") {
@@ -118,11 +165,121 @@ func issueOverride(host string, override interface{}) interface{} {
 		boot.BootSourceOverrideEnabled = redfish.OnceBootSourceOverrideEnabled
 	}
 
-	err = systems[0].SetBoot(boot)
-	o.Target = override.(redfish.BootSourceOverrideTarget)
+	return mode, system.SetBoot(boot)
+}
+
+// overrideMode resolves the requested boot mode (uefi, legacy, or auto) for a system, checking it against the
+// BootSourceOverrideMode values the BMC allows (if it reports any). The auto mode is the system's current mode.
+func overrideMode(system *redfish.ComputerSystem, requested string) (redfish.BootSourceOverrideMode, error) {
+	var mode redfish.BootSourceOverrideMode
+
+	switch strings.ToLower(requested) {
+	case "uefi":
+		mode = redfish.UEFIBootSourceOverrideMode
+	case "legacy":
+		mode = redfish.LegacyBootSourceOverrideMode
+	case "auto":
+		return system.Boot.BootSourceOverrideMode, nil
+	default:
+		return mode, fmt.Errorf(
+			"invalid boot mode: %s",
+			requested,
+		)
+	}
+
+	boot, err := rawBoot(system)
 	if err != nil {
-		o.Error = err
+		return mode, err
 	}
+	allowed := allowableValues(
+		boot,
+		"BootSourceOverrideMode",
+	)
+	if len(allowed) == 0 {
+		return mode, nil
+	}
+	for _, a := range allowed {
+		if a == string(mode) {
+			return mode, nil
+		}
+	}
+	return mode, fmt.Errorf(
+		"boot mode %s is not supported, allowed modes: %s",
+		mode,
+		strings.Join(
+			allowed,
+			", ",
+		),
+	)
+}
 
-	return o
+// rawBoot returns the Boot object of a system as-is, gofish drops the annotations (e.g. AllowableValues) and does
+// not distinguish missing properties from empty ones.
+func rawBoot(system *redfish.ComputerSystem) (map[string]interface{}, error) {
+	resp, err := system.GetClient().Get(system.ODataID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var s struct {
+		Boot map[string]interface{}
+	}
+	err = json.NewDecoder(resp.Body).Decode(&s)
+	if err != nil {
+		return nil, err
+	}
+	if s.Boot == nil {
+		s.Boot = make(map[string]interface{})
+	}
+	return s.Boot, nil
+}
+
+// allowableValues returns the @Redfish.AllowableValues annotation of a property in a raw object.
+func allowableValues(object map[string]interface{}, property string) []string {
+	var allowed []string
+	values, ok := object[property+"@Redfish.AllowableValues"].([]interface{})
+	if !ok {
+		return allowed
+	}
+	for _, value := range values {
+		allowed = append(
+			allowed,
+			fmt.Sprintf(
+				"%v",
+				value,
+			),
+		)
+	}
+	return allowed
+}
+
+// resetAfterOverride resets every host whose boot override succeeded if `--now` was given, with the ResetType given
+// by `--reset-type`.
+func resetAfterOverride(content map[string]interface{}) {
+	v := viper.GetViper()
+	if !v.GetBool("now") {
+		return
+	}
+
+	var hosts []string
+	for host, result := range content {
+		if o, ok := result.(Override); ok && o.Error == nil {
+			hosts = append(
+				hosts,
+				host,
+			)
+		}
+	}
+	if len(hosts) == 0 {
+		return
+	}
+	sort.Strings(hosts)
+
+	content = set.Async(
+		power.Issue,
+		hosts,
+		redfish.ResetType(v.GetString("reset-type")),
+	)
+	cli.PrettyPrint(content)
 }
@@ -0,0 +1,161 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package boot
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// uriTemplateData holds the per-host values available to an HTTP boot URI template.
+type uriTemplateData struct {
+	Host   string
+	Serial string
+	MAC    string
+}
+
+// issueHTTPOverride renders the HTTP boot URI template for a host, and overrides the next boot with UEFI HTTP boot
+// from that URI. The BMC must advertise HttpBootUri.
+func issueHTTPOverride(host string, uri interface{}) interface{} {
+	o := Override{Target: redfish.UefiHTTPBootSourceOverrideTarget}
+	tmpl := uri.(*template.Template)
+
+	system, logout, err := getSystem(host)
+	if err != nil {
+		o.Error = err
+		return o
+	}
+	defer logout()
+
+	supported, err := supportsHTTPBootURI(system)
+	if err != nil {
+		o.Error = err
+		return o
+	}
+	if !supported {
+		o.Error = fmt.Errorf("the BMC does not advertise HttpBootUri support")
+		return o
+	}
+
+	data := uriTemplateData{
+		Host:   host,
+		Serial: strings.TrimSpace(system.SerialNumber),
+	}
+	if usesSerial(tmpl) && data.Serial == "" {
+		o.Error = fmt.Errorf("the system has no serial number for the HTTP boot URI")
+		return o
+	}
+	if usesMAC(tmpl) {
+		data.MAC, err = bootMAC(system)
+		if err != nil {
+			o.Error = err
+			return o
+		}
+		if data.MAC == "" {
+			o.Error = fmt.Errorf("unable to determine a MAC address for the HTTP boot URI")
+			return o
+		}
+	}
+
+	var rendered bytes.Buffer
+	err = tmpl.Execute(
+		&rendered,
+		data,
+	)
+	if err != nil {
+		o.Error = err
+		return o
+	}
+	o.HTTPBootURI = rendered.String()
+
+	o.Mode, err = applyOverride(
+		system,
+		redfish.Boot{
+			BootSourceOverrideTarget: redfish.UefiHTTPBootSourceOverrideTarget,
+			HTTPBootURI:              o.HTTPBootURI,
+		},
+	)
+	if err != nil {
+		o.Error = err
+	}
+	return o
+}
+
+// usesMAC reports whether a URI template references {{.MAC}}, the MAC is only looked up for templates that use it.
+func usesMAC(tmpl *template.Template) bool {
+	return strings.Contains(
+		tmpl.Root.String(),
+		".MAC",
+	)
+}
+
+// usesSerial reports whether a URI template references {{.Serial}}, an empty serial number is only an error for
+// templates that use it.
+func usesSerial(tmpl *template.Template) bool {
+	return strings.Contains(
+		tmpl.Root.String(),
+		".Serial",
+	)
+}
+
+// supportsHTTPBootURI reports whether the system's Boot object has the HttpBootUri property.
+func supportsHTTPBootURI(system *redfish.ComputerSystem) (bool, error) {
+	boot, err := rawBoot(system)
+	if err != nil {
+		return false, err
+	}
+	_, ok := boot["HttpBootUri"]
+	return ok, nil
+}
+
+// bootMAC returns the MAC address of the first network boot option in the BootOrder, falling back to the first
+// enabled EthernetInterface of the system. An empty string is returned if neither has a MAC address.
+func bootMAC(system *redfish.ComputerSystem) (string, error) {
+	options, err := getBootOptions(system)
+	if err == nil {
+		for _, o := range options {
+			if o.Class == NetworkClass && o.MAC() != "" {
+				return o.MAC(), nil
+			}
+		}
+	}
+
+	interfaces, err := system.EthernetInterfaces()
+	if err != nil {
+		return "", err
+	}
+	for _, i := range interfaces {
+		if i.InterfaceEnabled && i.MACAddress != "" {
+			return strings.ToLower(i.MACAddress), nil
+		}
+	}
+	return "", nil
+}
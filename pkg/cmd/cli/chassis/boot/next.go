@@ -0,0 +1,191 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package boot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewNextCommand creates the `next` subcommand for `boot`.
+func NewNextCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "next option host [...host]",
+		Short: "Boot a specific UEFI boot option",
+		Long: `Override the next boot with a specific UEFI boot option (UefiBootNext), the option must match
+exactly one boot option on each host. The option is one of:
+  - mac:<address>        the MAC address in the option's UEFI device path
+  - Boot0001 or 0001     a boot option reference or ID
+  - "*IPv4*Mellanox*"    a case-insensitive display name pattern, * and ? are wildcards
+
+Alternatively, --uefi-target boots an explicit UEFI device path (UefiTarget) and no option is given.
+
+See 'gru chassis boot order show' for the available boot options.`,
+		Run: func(c *cobra.Command, args []string) {
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			var content map[string]interface{}
+			var hosts []string
+
+			if uefiTarget := v.GetString("uefi-target"); uefiTarget != "" {
+				hosts = cli.ParseHosts(args)
+				content = set.Async(
+					issueUefiTarget,
+					hosts,
+					uefiTarget,
+				)
+			} else {
+				if len(args) < 1 {
+					cmd.CheckError(fmt.Errorf("no boot option given"))
+				}
+				hosts = cli.ParseHosts(args[1:])
+				content = set.Async(
+					issueBootNext,
+					hosts,
+					args[0],
+				)
+			}
+			cli.PrettyPrint(content)
+			resetAfterOverride(content)
+		},
+	}
+	c.PersistentFlags().String(
+		"uefi-target",
+		"",
+		"Boot an explicit UEFI device path instead of a boot option",
+	)
+	set.AddFlags(c)
+	return c
+}
+
+// issueBootNext sets BootNext to the boot option matching the selector and overrides the next boot with it.
+func issueBootNext(host string, selector interface{}) interface{} {
+	o := Override{Target: redfish.UefiBootNextBootSourceOverrideTarget}
+
+	system, logout, err := getSystem(host)
+	if err != nil {
+		o.Error = err
+		return o
+	}
+	defer logout()
+
+	options, err := getBootOptions(system)
+	if err != nil {
+		o.Error = err
+		return o
+	}
+
+	matched, err := matchOptions(
+		selector.(string),
+		options,
+	)
+	if err != nil {
+		o.Error = err
+		return o
+	}
+	switch len(matched) {
+	case 0:
+		o.Error = fmt.Errorf(
+			"no boot option matches %q",
+			selector,
+		)
+		return o
+	case 1:
+	default:
+		var names []string
+		for _, m := range matched {
+			names = append(
+				names,
+				fmt.Sprintf(
+					"%s (%s)",
+					m.Reference,
+					m.DisplayName,
+				),
+			)
+		}
+		o.Error = fmt.Errorf(
+			"%q matches %d boot options: %s",
+			selector,
+			len(matched),
+			strings.Join(
+				names,
+				", ",
+			),
+		)
+		return o
+	}
+
+	o.BootNext = matched[0].Reference
+	o.Mode, err = applyOverride(
+		system,
+		redfish.Boot{
+			BootSourceOverrideTarget: redfish.UefiBootNextBootSourceOverrideTarget,
+			BootNext:                 o.BootNext,
+		},
+	)
+	if err != nil {
+		o.Error = err
+	}
+	return o
+}
+
+// issueUefiTarget overrides the next boot with an explicit UEFI device path.
+func issueUefiTarget(host string, path interface{}) interface{} {
+	o := Override{
+		Target:     redfish.UefiTargetBootSourceOverrideTarget,
+		UefiTarget: path.(string),
+	}
+
+	system, logout, err := getSystem(host)
+	if err != nil {
+		o.Error = err
+		return o
+	}
+	defer logout()
+
+	o.Mode, err = applyOverride(
+		system,
+		redfish.Boot{
+			BootSourceOverrideTarget:     redfish.UefiTargetBootSourceOverrideTarget,
+			UefiTargetBootSourceOverride: o.UefiTarget,
+		},
+	)
+	if err != nil {
+		o.Error = err
+	}
+	return o
+}
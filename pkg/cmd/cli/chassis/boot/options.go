@@ -0,0 +1,302 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package boot
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/glob"
+	"github.com/Cray-HPE/gru/internal/query"
+)
+
+// Option represents a single UEFI boot option.
+type Option struct {
+	Reference      string `json:"reference" yaml:"reference"`
+	ID             string `json:"id" yaml:"id"`
+	DisplayName    string `json:"displayName" yaml:"display_name"`
+	Description    string `json:"description" yaml:"description"`
+	UefiDevicePath string `json:"uefiDevicePath" yaml:"uefi_device_path"`
+	Class          string `json:"class" yaml:"class"`
+	Enabled        bool   `json:"enabled" yaml:"enabled"`
+}
+
+// Device classes a boot option may belong to, derived from its alias or UEFI device path.
+const (
+	NetworkClass = "network"
+	DiskClass    = "disk"
+	CDClass      = "cd"
+	USBClass     = "usb"
+	ShellClass   = "shell"
+	OtherClass   = "other"
+)
+
+// bootOption is a redfish.BootOption, some vendors (e.g. Gigabyte) only describe the option in Description.
+type bootOption struct {
+	redfish.BootOption
+	Description string
+}
+
+var macPattern = regexp.MustCompile(`(?i)MAC\(([0-9a-f]{12})`)
+
+// getBootOptions returns every boot option of a system sorted by its position in the BootOrder, options that are
+// not in the BootOrder follow in order of their reference.
+func getBootOptions(system *redfish.ComputerSystem) ([]Option, error) {
+	client := system.GetClient()
+	found, err := common.GetCollectionObjects[bootOption](
+		client,
+		bootOptionsLink(system),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make(
+		[]Option,
+		0,
+		len(found),
+	)
+	for _, o := range found {
+		option := Option{
+			Reference:      strings.TrimSpace(o.BootOptionReference),
+			ID:             o.ID,
+			DisplayName:    strings.TrimSpace(o.DisplayName),
+			UefiDevicePath: strings.TrimSpace(o.UefiDevicePath),
+			Description:    strings.TrimSpace(o.Description),
+			Enabled:        o.BootOptionEnabled,
+		}
+		if option.Reference == "" {
+			option.Reference = fmt.Sprintf(
+				"Boot%s",
+				o.ID,
+			)
+		}
+		if option.DisplayName == "" {
+			option.DisplayName = option.Description
+		}
+		if option.DisplayName == "" {
+			option.DisplayName = strings.TrimSpace(o.Name)
+		}
+		option.Class = deviceClass(
+			o.Alias,
+			option.UefiDevicePath,
+			option.DisplayName,
+		)
+		options = append(
+			options,
+			option,
+		)
+	}
+
+	position := make(map[string]int)
+	for i, ref := range system.Boot.BootOrder {
+		position[ref] = i
+	}
+	sort.SliceStable(options, func(i, j int) bool {
+		pi, iok := position[options[i].Reference]
+		pj, jok := position[options[j].Reference]
+		if iok && jok {
+			return pi < pj
+		}
+		if iok != jok {
+			return iok
+		}
+		return options[i].Reference < options[j].Reference
+	})
+	return options, nil
+}
+
+// bootOptionsLink returns the BootOptions link of a system's Boot object, falling back to the conventional
+// BootOptions URI of the system since not every BMC (e.g. Gigabyte) links them from Boot.
+func bootOptionsLink(system *redfish.ComputerSystem) string {
+	var raw struct {
+		Boot struct {
+			BootOptions common.Link
+		}
+	}
+	err := query.GetJSON(
+		system.GetClient(),
+		system.ODataID,
+		&raw,
+	)
+	if err == nil && raw.Boot.BootOptions != "" {
+		return raw.Boot.BootOptions.String()
+	}
+	return fmt.Sprintf(
+		"%s/%s",
+		strings.TrimRight(
+			system.ODataID,
+			"/",
+		),
+		"BootOptions",
+	)
+}
+
+// deviceClass determines the device class of a boot option from its alias, falling back to its UEFI device
+// path and finally its display name.
+func deviceClass(alias redfish.BootSourceOverrideTarget, path string, name string) string {
+	switch alias {
+	case redfish.PxeBootSourceOverrideTarget, redfish.UefiHTTPBootSourceOverrideTarget:
+		return NetworkClass
+	case redfish.HddBootSourceOverrideTarget:
+		return DiskClass
+	case redfish.CdBootSourceOverrideTarget:
+		return CDClass
+	case redfish.UsbBootSourceOverrideTarget:
+		return USBClass
+	case redfish.UefiShellBootSourceOverrideTarget:
+		return ShellClass
+	}
+
+	p := strings.ToLower(path)
+	switch {
+	case strings.Contains(p, "mac("), strings.Contains(p, "ipv4("), strings.Contains(p, "ipv6("), strings.Contains(p, "uri("):
+		return NetworkClass
+	case strings.Contains(p, "cdrom("):
+		return CDClass
+	case strings.Contains(p, "usb("):
+		return USBClass
+	case strings.Contains(p, "nvme("), strings.Contains(p, "sata("), strings.Contains(p, "scsi("), strings.Contains(p, "sas("), strings.Contains(p, "hd("):
+		return DiskClass
+	}
+
+	n := strings.ToLower(name)
+	switch {
+	case strings.Contains(n, "pxe"), strings.Contains(n, "ipv4"), strings.Contains(n, "ipv6"), strings.Contains(n, "http"):
+		return NetworkClass
+	case strings.Contains(n, "shell"):
+		return ShellClass
+	case strings.Contains(n, "usb"):
+		return USBClass
+	case strings.Contains(n, "cd"), strings.Contains(n, "dvd"):
+		return CDClass
+	}
+	return OtherClass
+}
+
+// MAC returns the MAC address in the option's UEFI device path, formatted as aa:bb:cc:dd:ee:ff, or an empty string.
+func (o Option) MAC() string {
+	m := macPattern.FindStringSubmatch(o.UefiDevicePath)
+	if m == nil {
+		return ""
+	}
+	hex := strings.ToLower(m[1])
+	octets := make(
+		[]string,
+		0,
+		6,
+	)
+	for i := 0; i < len(hex); i += 2 {
+		octets = append(
+			octets,
+			hex[i:i+2],
+		)
+	}
+	return strings.Join(
+		octets,
+		":",
+	)
+}
+
+// matchOptions returns the options matching a selector, in the order given. A selector is one of:
+//   - class:<class>, e.g. class:network
+//   - mac:<address>, e.g. mac:0c:42:a1:b2:c3:d4
+//   - a boot option reference or ID, e.g. Boot0001 or 0001
+//   - a case-insensitive display name pattern where * and ? are wildcards, e.g. "*IPv4*Mellanox*"
+func matchOptions(selector string, options []Option) ([]Option, error) {
+	var matched []Option
+
+	if class, ok := strings.CutPrefix(selector, "class:"); ok {
+		for _, o := range options {
+			if strings.EqualFold(o.Class, class) {
+				matched = append(
+					matched,
+					o,
+				)
+			}
+		}
+		return matched, nil
+	}
+
+	if mac, ok := strings.CutPrefix(selector, "mac:"); ok {
+		mac = normalizeMAC(mac)
+		for _, o := range options {
+			if o.MAC() != "" && normalizeMAC(o.MAC()) == mac {
+				matched = append(
+					matched,
+					o,
+				)
+			}
+		}
+		return matched, nil
+	}
+
+	for _, o := range options {
+		if strings.EqualFold(o.Reference, selector) || strings.EqualFold(o.ID, selector) {
+			return []Option{o}, nil
+		}
+	}
+
+	pattern := glob.Compile(selector)
+	for _, o := range options {
+		if pattern.MatchString(o.DisplayName) {
+			matched = append(
+				matched,
+				o,
+			)
+		}
+	}
+	return matched, nil
+}
+
+// Find returns the boot options of a system matching a selector, see matchOptions.
+func Find(system *redfish.ComputerSystem, selector string) ([]Option, error) {
+	options, err := getBootOptions(system)
+	if err != nil {
+		return nil, err
+	}
+	return matchOptions(
+		selector,
+		options,
+	)
+}
+
+// normalizeMAC strips separators from a MAC address and lowercases it.
+func normalizeMAC(mac string) string {
+	return strings.ToLower(
+		strings.NewReplacer(
+			":", "",
+			"-", "",
+			".", "",
+		).Replace(mac),
+	)
+}
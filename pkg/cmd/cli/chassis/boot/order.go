@@ -0,0 +1,255 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package boot
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// Order represents the persistent boot order and the available boot options of a system.
+type Order struct {
+	Order   []string `json:"order" yaml:"order"`
+	Options []Option `json:"options,omitempty" yaml:"options,omitempty"`
+	Error   error    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewOrderCommand creates the `order` subcommand for `boot`.
+func NewOrderCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "order",
+		Short: "Persistent boot order",
+		Long:  `Show the boot options and the persistent BootOrder, or set a new BootOrder`,
+	}
+	c.AddCommand(
+		NewOrderSetCommand(),
+		NewOrderShowCommand(),
+	)
+	return c
+}
+
+// NewOrderShowCommand creates the `show` subcommand for `order`.
+func NewOrderShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "show host [...host]",
+		Short: "Show the boot order",
+		Long:  `Show the BootOrder and every BootOption with its reference, display name, description, UEFI device path, device class, and enabled state`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+			content := query.Async(
+				getBootOrder,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	return c
+}
+
+// NewOrderSetCommand creates the `set` subcommand for `order`.
+func NewOrderSetCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "set host [...host]",
+		Short: "Set the boot order",
+		Long: `Sets a new persistent BootOrder. The boot options matching each selector are moved to the front
+of the BootOrder in the order the selectors are given, all other boot options keep their relative order.
+
+A selector is one of:
+  - class:<class>        a device class; network, disk, cd, usb, shell, or other
+  - mac:<address>        the MAC address in the option's UEFI device path
+  - Boot0001 or 0001     a boot option reference or ID
+  - "*IPv4*Mellanox*"    a case-insensitive display name pattern, * and ? are wildcards
+
+Example, network boot first with the Mellanox NIC ahead of any other network option:
+
+  gru chassis boot order set --order "*IPv4*Mellanox*",class:network host`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			selectors := v.GetStringSlice("order")
+			if len(selectors) == 0 {
+				cmd.CheckError(fmt.Errorf("at least one selector must be given with --order"))
+			}
+
+			content := set.Async(
+				setBootOrder,
+				hosts,
+				selectors,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	c.PersistentFlags().StringSliceP(
+		"order",
+		"o",
+		[]string{},
+		"Comma delimited list of boot option selectors to move to the front of the boot order",
+	)
+	set.AddFlags(c)
+	return c
+}
+
+// getSystem returns the host's first system, the caller must log out of the returned client.
+func getSystem(host string) (*redfish.ComputerSystem, func(), error) {
+	c, err := auth.Connection(host)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	systems, err := c.Service.Systems()
+	if err != nil {
+		c.Logout()
+		return nil, nil, err
+	}
+	if len(systems) < 1 {
+		c.Logout()
+		return nil, nil, fmt.Errorf("no systems found")
+	}
+	return systems[0], c.Logout, nil
+}
+
+// getBootOrder retrieves the BootOrder and the BootOptions of a host.
+func getBootOrder(host string) interface{} {
+	order := Order{Order: []string{}}
+
+	system, logout, err := getSystem(host)
+	if err != nil {
+		order.Error = err
+		return order
+	}
+	defer logout()
+
+	order.Order = append(
+		order.Order,
+		system.Boot.BootOrder...,
+	)
+	order.Options, err = getBootOptions(system)
+	if err != nil {
+		order.Error = err
+	}
+	return order
+}
+
+// setBootOrder reorders the BootOrder of a host by the given selectors.
+func setBootOrder(host string, data interface{}) interface{} {
+	order := Order{Order: []string{}}
+
+	system, logout, err := getSystem(host)
+	if err != nil {
+		order.Error = err
+		return order
+	}
+	defer logout()
+
+	options, err := getBootOptions(system)
+	if err != nil {
+		order.Error = err
+		return order
+	}
+
+	newOrder, err := reorder(
+		system.Boot.BootOrder,
+		options,
+		data.([]string),
+	)
+	if err != nil {
+		order.Order = system.Boot.BootOrder
+		order.Error = err
+		return order
+	}
+
+	err = system.SetBoot(
+		redfish.Boot{
+			BootOrder: newOrder,
+		},
+	)
+	if err != nil {
+		order.Order = system.Boot.BootOrder
+		order.Error = err
+		return order
+	}
+
+	order.Order = newOrder
+	return order
+}
+
+// reorder moves the boot options matching each selector to the front of the current boot order.
+func reorder(current []string, options []Option, selectors []string) ([]string, error) {
+	var newOrder []string
+	placed := make(map[string]bool)
+
+	for _, selector := range selectors {
+		matched, err := matchOptions(
+			selector,
+			options,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(matched) == 0 {
+			return nil, fmt.Errorf(
+				"no boot option matches %q",
+				selector,
+			)
+		}
+		for _, o := range matched {
+			if placed[o.Reference] {
+				continue
+			}
+			placed[o.Reference] = true
+			newOrder = append(
+				newOrder,
+				o.Reference,
+			)
+		}
+	}
+
+	for _, ref := range current {
+		if placed[ref] {
+			continue
+		}
+		placed[ref] = true
+		newOrder = append(
+			newOrder,
+			ref,
+		)
+	}
+	return newOrder, nil
+}
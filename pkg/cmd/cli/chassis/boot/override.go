@@ -27,6 +27,8 @@
 package boot
 
 import (
+	"text/template"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stmcginnis/gofish/redfish"
@@ -34,7 +36,6 @@ import (
 	"github.com/Cray-HPE/gru/internal/set"
 	"github.com/Cray-HPE/gru/pkg/cmd"
 	"github.com/Cray-HPE/gru/pkg/cmd/cli"
-	"github.com/Cray-HPE/gru/pkg/cmd/cli/chassis/power"
 )
 
 // NewBiosOverrideCommand creates the `bios` subcommand for `boot`.
@@ -56,16 +57,10 @@ func NewBiosOverrideCommand() *cobra.Command {
 				redfish.BiosSetupBootSourceOverrideTarget,
 			)
 			cli.PrettyPrint(content)
-			if v.GetBool("now") {
-				content = set.Async(
-					power.Issue,
-					hosts,
-					redfish.ForceRestartResetType,
-				)
-				cli.PrettyPrint(content)
-			}
+			resetAfterOverride(content)
 		},
 	}
+	set.AddFlags(c)
 	return c
 }
 
@@ -83,8 +78,10 @@ func NewPxeOverrideCommand() *cobra.Command {
 				redfish.PxeBootSourceOverrideTarget,
 			)
 			cli.PrettyPrint(content)
+			resetAfterOverride(content)
 		},
 	}
+	set.AddFlags(c)
 	return c
 }
 
@@ -102,27 +99,84 @@ func NewHddOverrideCommand() *cobra.Command {
 				redfish.HddBootSourceOverrideTarget,
 			)
 			cli.PrettyPrint(content)
+			resetAfterOverride(content)
 		},
 	}
+	set.AddFlags(c)
 	return c
 }
 
-// NewUEFIHttpOverrideCommand creates the `http` subcommand for `boot`.
-func NewUEFIHttpOverrideCommand() *cobra.Command {
+// NewCdOverrideCommand creates the `cd` subcommand for `boot`.
+func NewCdOverrideCommand() *cobra.Command {
 	c := &cobra.Command{
-		Use:   "http host [...host]",
-		Short: "Boot with HTTP",
-		Long:  `Override the next boot with the HTTP option`,
+		Use:   "cd host [...host]",
+		Short: "Boot from the CD",
+		Long:  `Override the next boot with the CD option, e.g. virtual media inserted with 'gru vmedia insert'`,
 		Run: func(c *cobra.Command, args []string) {
 			hosts := cli.ParseHosts(args)
 			content := set.Async(
 				issueOverride,
 				hosts,
-				redfish.UefiHTTPBootSourceOverrideTarget,
+				redfish.CdBootSourceOverrideTarget,
 			)
 			cli.PrettyPrint(content)
+			resetAfterOverride(content)
+		},
+	}
+	set.AddFlags(c)
+	return c
+}
+
+// NewUEFIHttpOverrideCommand creates the `http` subcommand for `boot`.
+func NewUEFIHttpOverrideCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "http host [...host]",
+		Short: "Boot with HTTP",
+		Long: `Override the next boot with the HTTP option.
+
+Optionally, --uri sets the HttpBootUri to boot from. The URI is a Go template rendered for each host with:
+  {{.Host}}      the host as given to gru
+  {{.Serial}}    the system's serial number
+  {{.MAC}}       the MAC address of the first network boot option (or the first enabled EthernetInterface)
+
+A host without the serial number or MAC address its template references fails rather than rendering an empty value.
+
+Example:
+
+  gru chassis boot http --uri 'http://deploy/{{.Serial}}.efi' host`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			var content map[string]interface{}
+			if uri := v.GetString("uri"); uri != "" {
+				tmpl, err := template.New("uri").Option("missingkey=error").Parse(uri)
+				cmd.CheckError(err)
+				content = set.Async(
+					issueHTTPOverride,
+					hosts,
+					tmpl,
+				)
+			} else {
+				content = set.Async(
+					issueOverride,
+					hosts,
+					redfish.UefiHTTPBootSourceOverrideTarget,
+				)
+			}
+			cli.PrettyPrint(content)
+			resetAfterOverride(content)
 		},
 	}
+	c.PersistentFlags().String(
+		"uri",
+		"",
+		"HTTP boot URI template, e.g. 'http://deploy/{{.Serial}}.efi'",
+	)
+	set.AddFlags(c)
 	return c
 }
 
@@ -140,7 +194,9 @@ func NewNoneOverrideCommand() *cobra.Command {
 				redfish.NoneBootSourceOverrideTarget,
 			)
 			cli.PrettyPrint(content)
+			resetAfterOverride(content)
 		},
 	}
+	set.AddFlags(c)
 	return c
 }
@@ -27,9 +27,6 @@
 package boot
 
 import (
-	"encoding/json"
-	"fmt"
-	"io"
 	"strings"
 
 	"github.com/stmcginnis/gofish/redfish"
@@ -38,7 +35,6 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/Cray-HPE/gru/pkg/auth"
 	"github.com/Cray-HPE/gru/pkg/cmd/cli"
 )
 
@@ -62,115 +58,45 @@ func NewShowCommand() *cobra.Command {
 
 func getBootInformation(host string) interface{} {
 	boot := Boot{Order: []string{}}
-	c, err := auth.Connection(host)
-	if err != nil {
-		boot.Error = err
-		return boot
-	}
 
-	defer c.Logout()
-
-	service := c.Service
-
-	systems, err := service.Systems()
-	if err != nil || len(systems) < 1 {
+	system, logout, err := getSystem(host)
+	if err != nil {
 		boot.Error = err
 		return boot
 	}
+	defer logout()
 
-	bo := fmt.Sprintf(
-		"%s/%s",
-		strings.TrimRight(
-			systems[0].ODataID,
-			"/",
-		),
-		"BootOptions",
-	)
-	client := systems[0].GetClient()
-	resp, err := client.Get(bo)
-
-	// GigaByte has this key
-	if err == nil || resp != nil {
-
-		opts := make(map[string]interface{})
-		err = json.NewDecoder(resp.Body).Decode(&opts)
-		if err != nil {
-			boot.Error = err
-			return boot
-		}
-
-		for _, b := range systems[0].Boot.BootOrder {
-			ep := fmt.Sprintf(
-				"%s/%s",
-				bo,
-				strings.TrimPrefix(
-					b,
-					"Boot",
-				),
-			)
-			resp, err := client.Get(ep)
-			if err != nil {
-				boot.Error = err
-				return boot
-			}
-			names := make(map[string]interface{})
-			err = json.NewDecoder(resp.Body).Decode(&names)
-			if err != nil {
-				boot.Error = err
-				return boot
-			}
-
+	// Show the boot options by description, falling back to their references if the BMC has no BootOptions.
+	names := getBootDescriptions(system)
+	for _, ref := range system.Boot.BootOrder {
+		if name, ok := names[ref]; ok && name != "" {
 			boot.Order = append(
 				boot.Order,
-				strings.TrimSpace(names["Description"].(string)),
+				name,
 			)
-		}
-	} else {
-
-		bo = strings.TrimRight(
-			systems[0].ODataID,
-			"/",
-		)
-		response, err := client.Get(bo)
-		if err != nil {
-			boot.Error = err
-			return boot
-		}
-
-		names := make(map[string]interface{})
-		body, err := io.ReadAll(response.Body)
-		err = json.Unmarshal(
-			body,
-			&names,
-		)
-		if err != nil {
-			boot.Error = err
-			return boot
-		}
-
-		bootMap := redfish.Boot{}
-		bootJSON, err := json.Marshal(names["Boot"])
-		if err != nil {
-			boot.Error = err
-			return boot
-		}
-
-		err = bootMap.UnmarshalJSON(bootJSON)
-		if err != nil {
-			boot.Error = err
-			return boot
-		}
-
-		for _, v := range bootMap.BootOrder {
+		} else {
 			boot.Order = append(
 				boot.Order,
-				strings.TrimSpace(v),
+				strings.TrimSpace(ref),
 			)
 		}
-
 	}
 
-	boot.Next = systems[0].Boot.BootNext
+	boot.Next = system.Boot.BootNext
 
 	return boot
 }
+
+// getBootDescriptions returns the Description of every boot option of a system by its reference, or nothing if the
+// BMC has no BootOptions.
+func getBootDescriptions(system *redfish.ComputerSystem) map[string]string {
+	descriptions := make(map[string]string)
+	options, err := getBootOptions(system)
+	if err != nil {
+		return descriptions
+	}
+	for _, o := range options {
+		descriptions[o.Reference] = o.Description
+	}
+	return descriptions
+}
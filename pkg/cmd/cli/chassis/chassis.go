@@ -44,6 +44,7 @@ func NewCommand() *cobra.Command {
 	c.AddCommand(
 		boot.NewCommand(),
 		power.NewCommand(),
+		power.NewEnclosureCommand(),
 	)
 	return c
 }
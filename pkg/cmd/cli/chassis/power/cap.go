@@ -0,0 +1,215 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package power
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// Cap represents a change of a chassis' power limit. LimitWatts is 0 when the limit was cleared.
+type Cap struct {
+	Chassis            string  `json:"chassis,omitempty" yaml:"chassis,omitempty"`
+	Source             string  `json:"source,omitempty" yaml:"source,omitempty"`
+	PreviousLimitWatts float32 `json:"previousLimitWatts" yaml:"previous_limit_watts"`
+	LimitWatts         float32 `json:"limitWatts" yaml:"limit_watts"`
+	Error              error   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewCapCommand creates the `cap` subcommand for `power`.
+func NewCapCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "cap",
+		Short: "Power capping",
+		Long:  `Set or clear the power limit (cap) of the target machine(s), see 'gru show power' for the current limit`,
+	}
+	c.AddCommand(
+		NewCapSetCommand(),
+		NewCapClearCommand(),
+	)
+	return c
+}
+
+// NewCapSetCommand creates the `set` subcommand for `cap`.
+func NewCapSetCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "set watts host [...host]",
+		Short: "Set a power limit",
+		Long:  `Limits the power consumption of the target machine(s) to the given number of watts`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) < 1 {
+				cmd.CheckError(fmt.Errorf("no watts given"))
+			}
+			watts, err := strconv.ParseFloat(
+				args[0],
+				32,
+			)
+			if err != nil || watts <= 0 {
+				cmd.CheckError(
+					fmt.Errorf(
+						"invalid watts: %s",
+						args[0],
+					),
+				)
+			}
+			hosts := cli.ParseHosts(args[1:])
+			content := set.Async(
+				setPowerLimit,
+				hosts,
+				float32(watts),
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	set.AddFlags(c)
+	return c
+}
+
+// NewCapClearCommand creates the `clear` subcommand for `cap`.
+func NewCapClearCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "clear host [...host]",
+		Short: "Clear the power limit",
+		Long:  `Removes the power limit of the target machine(s)`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+			content := set.Async(
+				setPowerLimit,
+				hosts,
+				float32(0),
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	set.AddFlags(c)
+	return c
+}
+
+// setPowerLimit sets the power limit of a host's chassis, or clears it when the limit is 0. The limit is set on the
+// first PowerControl of the Power resource, or on the EnvironmentMetrics when the chassis has a PowerSubsystem.
+func setPowerLimit(host string, data any) interface{} {
+	limit := data.(float32)
+	pc := Cap{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		pc.Error = err
+		return pc
+	}
+	defer c.Logout()
+
+	chassis, power, _, err := powerChassis(c.Service)
+	if err != nil {
+		pc.Error = err
+		return pc
+	}
+	pc.Chassis = chassis.ID
+
+	var uri string
+	var payload interface{}
+	if power != nil {
+		pc.Source = PowerSource
+		if len(power.PowerControl) < 1 {
+			pc.Error = fmt.Errorf("no power control found")
+			return pc
+		}
+		pc.PreviousLimitWatts = power.PowerControl[0].PowerLimit.LimitInWatts
+
+		var limitInWatts interface{}
+		if limit > 0 {
+			limitInWatts = limit
+		}
+		uri = power.ODataID
+		payload = map[string]interface{}{
+			"PowerControl": []interface{}{
+				map[string]interface{}{
+					"PowerLimit": map[string]interface{}{
+						"LimitInWatts": limitInWatts,
+					},
+				},
+			},
+		}
+	} else {
+		pc.Source = PowerSubsystemSource
+		metrics, err := chassis.EnvironmentMetrics()
+		if err != nil {
+			pc.Error = err
+			return pc
+		}
+		if metrics == nil {
+			pc.Error = fmt.Errorf("no environment metrics found, the chassis does not support power limits")
+			return pc
+		}
+		control := metrics.PowerLimitWatts
+		if control.ControlMode != redfish.DisabledControlMode {
+			pc.PreviousLimitWatts = float32(control.SetPoint)
+		}
+		if limit > 0 && control.AllowableMax > 0 && (float64(limit) > control.AllowableMax || float64(limit) < control.AllowableMin) {
+			pc.Error = fmt.Errorf(
+				"%v watts is outside of the allowable limits: %v to %v watts",
+				limit,
+				control.AllowableMin,
+				control.AllowableMax,
+			)
+			return pc
+		}
+
+		uri = metrics.ODataID
+		payload = map[string]interface{}{
+			"PowerLimitWatts": map[string]interface{}{
+				"ControlMode": redfish.DisabledControlMode,
+			},
+		}
+		if limit > 0 {
+			payload = map[string]interface{}{
+				"PowerLimitWatts": map[string]interface{}{
+					"ControlMode": redfish.AutomaticControlMode,
+					"SetPoint":    limit,
+				},
+			}
+		}
+	}
+
+	resp, err := c.Patch(
+		uri,
+		payload,
+	)
+	if err != nil {
+		pc.Error = err
+		return pc
+	}
+	defer resp.Body.Close()
+	pc.LimitWatts = limit
+	return pc
+}
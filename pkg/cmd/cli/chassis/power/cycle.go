@@ -65,6 +65,7 @@ Also allows bypassing the OS shutdown, forcing a warm boot.`,
 				resetType,
 			)
 			cli.PrettyPrint(content)
+			checkWait(content)
 		},
 		Hidden: false,
 	}
@@ -76,5 +77,16 @@ Also allows bypassing the OS shutdown, forcing a warm boot.`,
 			"Immediately restart waiting for the OS (warm boot)",
 		),
 	)
+	c.PersistentFlags().Duration(
+		"escalate-after",
+		0,
+		"Issue a ForceRestart to hosts that have not begun to transition within the given duration of the graceful request",
+	)
+	c.MarkFlagsMutuallyExclusive(
+		"escalate-after",
+		"force",
+	)
+	addWaitFlag(c)
+	set.AddFlags(c)
 	return c
 }
@@ -0,0 +1,273 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package power
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/internal/wait"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// ChassisStateChange represents a change in a chassis' power state. FinalPowerState and Elapsed are only set when
+// waiting for the change to complete.
+type ChassisStateChange struct {
+	Chassis             string             `json:"chassis,omitempty" yaml:"chassis,omitempty"`
+	PreviousPowerState  redfish.PowerState `json:"previousPowerState,omitempty" yaml:"previous_power_state,omitempty"`
+	RequestedPowerState redfish.ResetType  `json:"requestedPowerState,omitempty" yaml:"requested_power_state,omitempty"`
+	ResetTypeAllowed    string             `json:"resetTypeAllowed,omitempty" yaml:"reset_type_allowed,omitempty"`
+	FinalPowerState     redfish.PowerState `json:"finalPowerState,omitempty" yaml:"final_power_state,omitempty"`
+	Elapsed             string             `json:"elapsed,omitempty" yaml:"elapsed,omitempty"`
+	Error               error              `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewEnclosureCommand creates the `enclosure` subcommand for `chassis`.
+func NewEnclosureCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "enclosure",
+		Short: "Enclosure power control",
+		Long: `Power on, off, cycle, or reset the Chassis resource(s) of a host (e.g. a whole enclosure), instead of
+the system. By default the outermost chassis that has a reset action is targeted.`,
+		Hidden: false,
+	}
+	c.AddCommand(
+		newEnclosureResetCommand(
+			"on",
+			"Power on the target enclosure(s)",
+			redfish.OnResetType,
+			"",
+		),
+		newEnclosureResetCommand(
+			"off",
+			"Power off the target enclosure(s)",
+			redfish.GracefulShutdownResetType,
+			redfish.ForceOffResetType,
+		),
+		newEnclosureResetCommand(
+			"cycle",
+			"Power cycle the target enclosure(s)",
+			redfish.PowerCycleResetType,
+			"",
+		),
+		newEnclosureResetCommand(
+			"reset",
+			"Restart the target enclosure(s)",
+			redfish.GracefulRestartResetType,
+			redfish.ForceRestartResetType,
+		),
+	)
+	c.PersistentFlags().String(
+		"chassis",
+		"",
+		"ID of the chassis to target, instead of the outermost chassis that has a reset action",
+	)
+	return c
+}
+
+// newEnclosureResetCommand creates an `enclosure` subcommand issuing the given reset type, or the forced reset type
+// when `--force` is given.
+func newEnclosureResetCommand(name string, short string, resetType redfish.ResetType, force redfish.ResetType) *cobra.Command {
+	c := &cobra.Command{
+		Use:   fmt.Sprintf("%s host [...host]", name),
+		Short: short,
+		Long: fmt.Sprintf(
+			"Issues %s to the Chassis resource(s) of the target machine(s)",
+			resetType,
+		),
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			action := resetType
+			if force != "" && v.GetBool("force") {
+				action = force
+			}
+
+			content := set.Async(
+				IssueChassis,
+				hosts,
+				action,
+			)
+			cli.PrettyPrint(content)
+			checkWait(content)
+		},
+		Hidden: false,
+	}
+	if force != "" {
+		c.PersistentFlags().BoolP(
+			"force",
+			"f",
+			false,
+			fmt.Sprintf(
+				"Issue %s instead of %s",
+				force,
+				resetType,
+			),
+		)
+	}
+	addWaitFlag(c)
+	set.AddFlags(c)
+	return c
+}
+
+// IssueChassis issues a reset against a host's chassis. If `--wait` was given, IssueChassis waits for the chassis to
+// reach the power state expected after the reset.
+func IssueChassis(host string, action interface{}) interface{} {
+	sc := ChassisStateChange{
+		RequestedPowerState: action.(redfish.ResetType),
+	}
+	c, err := auth.Connection(host)
+	if err != nil {
+		sc.Error = err
+		return sc
+	}
+	defer c.Logout()
+
+	chassis, err := resetChassis(
+		c.Service,
+		viper.GetString("chassis"),
+	)
+	if err != nil {
+		sc.Error = err
+		return sc
+	}
+	sc.Chassis = chassis.ID
+	sc.PreviousPowerState = chassis.PowerState
+	sc.ResetTypeAllowed = resetTypeAllowed(
+		chassis.SupportedResetTypes,
+		sc.RequestedPowerState,
+	)
+	if sc.ResetTypeAllowed == "no" {
+		sc.Error = fmt.Errorf(
+			"%s is not an allowable reset type, the chassis allows: %v",
+			sc.RequestedPowerState,
+			chassis.SupportedResetTypes,
+		)
+		return sc
+	}
+
+	start := time.Now()
+	err = chassis.Reset(sc.RequestedPowerState)
+	if err != nil {
+		sc.Error = err
+		return sc
+	}
+
+	if timeout := wait.Timeout(); timeout > 0 {
+		sc.FinalPowerState, err = watchPowerState(
+			watchedChassis(chassis),
+			sc.RequestedPowerState,
+			timeout,
+			false,
+		)
+		sc.Elapsed = time.Since(start).Round(time.Second).String()
+		if err != nil {
+			sc.Error = err
+		}
+	}
+	return sc
+}
+
+// resetChassis returns the chassis with the given ID, or if no ID is given, the outermost chassis with a reset action.
+func resetChassis(service *gofish.Service, id string) (*redfish.Chassis, error) {
+	chassis, err := service.Chassis()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*redfish.Chassis
+	for _, ch := range chassis {
+		if id != "" {
+			if ch.ID == id {
+				return ch, nil
+			}
+			continue
+		}
+		reset, contained, err := chassisLinks(ch)
+		if err != nil {
+			return nil, err
+		}
+		if !reset {
+			continue
+		}
+		if !contained {
+			return ch, nil
+		}
+		candidates = append(
+			candidates,
+			ch,
+		)
+	}
+	if id != "" {
+		return nil, fmt.Errorf(
+			"chassis %s not found",
+			id,
+		)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no chassis with a reset action found")
+	}
+	return candidates[0], nil
+}
+
+// chassisLinks returns whether a chassis has a reset action, and whether it is contained by another chassis.
+func chassisLinks(chassis *redfish.Chassis) (bool, bool, error) {
+	resp, err := chassis.GetClient().Get(chassis.ODataID)
+	if err != nil {
+		return false, false, err
+	}
+	defer resp.Body.Close()
+
+	var raw struct {
+		Actions map[string]struct {
+			Target string `json:"target"`
+		}
+		Links struct {
+			ContainedBy struct {
+				ODataID string `json:"@odata.id"`
+			}
+		}
+	}
+	err = json.NewDecoder(resp.Body).Decode(&raw)
+	if err != nil {
+		return false, false, err
+	}
+	return raw.Actions["#Chassis.Reset"].Target != "", raw.Links.ContainedBy.ODataID != "", nil
+}
@@ -51,5 +51,6 @@ func NewPowerNMICommand() *cobra.Command {
 		},
 		Hidden: false,
 	}
+	set.AddFlags(c)
 	return c
 }
@@ -45,7 +45,8 @@ func NewPowerOffCommand() *cobra.Command {
 		Short: "Power off the target machine(s)",
 		Long: `Powers off the target machine(s) with an ACPI shutdown.
 Permits forcing a shutdown (without waiting for the OS),
-as well as a power-button emulated shutdown.`,
+as well as a power-button emulated shutdown.
+Hosts that are already off are left unchanged unless --always is given.`,
 		Run: func(c *cobra.Command, args []string) {
 			var resetType redfish.ResetType
 
@@ -69,6 +70,7 @@ as well as a power-button emulated shutdown.`,
 				resetType,
 			)
 			cli.PrettyPrint(content)
+			checkWait(content)
 		},
 	}
 	c.PersistentFlags().BoolP(
@@ -89,5 +91,18 @@ as well as a power-button emulated shutdown.`,
 		"button",
 		"force",
 	)
+	c.PersistentFlags().Duration(
+		"escalate-after",
+		0,
+		"Issue a ForceOff to hosts that have not begun to transition within the given duration of the graceful request",
+	)
+	c.MarkFlagsMutuallyExclusive(
+		"escalate-after",
+		"force",
+		"button",
+	)
+	addAlwaysFlag(c)
+	addWaitFlag(c)
+	set.AddFlags(c)
 	return c
 }
@@ -39,7 +39,8 @@ func NewPowerOnCommand() *cobra.Command {
 	c := &cobra.Command{
 		Use: "on host [...host]",
 		Short: "Power on the target machine(s)",
-		Long: `Powers on the target machines (cold boot)`,
+		Long: `Powers on the target machines (cold boot).
+Hosts that are already on are left unchanged unless --always is given.`,
 		Run: func(c *cobra.Command, args []string) {
 			hosts := cli.ParseHosts(args)
 			content := set.Async(
@@ -48,7 +49,11 @@ func NewPowerOnCommand() *cobra.Command {
 				redfish.OnResetType,
 			)
 			cli.PrettyPrint(content)
+			checkWait(content)
 		},
 	}
+	addAlwaysFlag(c)
+	addWaitFlag(c)
+	set.AddFlags(c)
 	return c
 }
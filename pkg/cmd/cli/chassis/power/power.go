@@ -27,9 +27,14 @@
 package power
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stmcginnis/gofish/redfish"
 
+	"github.com/Cray-HPE/gru/internal/wait"
 	"github.com/Cray-HPE/gru/pkg/auth"
 )
 
@@ -48,14 +53,30 @@ func NewCommand() *cobra.Command {
 		NewPowerCycleCommand(),
 		NewPowerStatusCommand(),
 		NewPowerNMICommand(),
+		NewCapCommand(),
 	)
 	return c
 }
 
-// StateChange represents a change in power states.
+// Statuses of a StateChange.
+const (
+	Issued    = "issued"
+	Unchanged = "unchanged"
+)
+
+// StateChange represents a change in power states. Status is Unchanged when the host was already in the requested
+// state, and ResetTypeAllowed is "unknown" when the host does not advertise its allowable reset types.
+// FinalPowerState and Elapsed are only set when waiting for the change to complete, Path and EscalatedPowerState are
+// only set when escalating a graceful request.
 type StateChange struct {
 	PreviousPowerState  redfish.PowerState `json:"previousPowerState,omitempty" yaml:"previous_power_state,omitempty"`
 	RequestedPowerState redfish.ResetType  `json:"requestedPowerState,omitempty" yaml:"requested_power_state,omitempty"`
+	ResetTypeAllowed    string             `json:"resetTypeAllowed,omitempty" yaml:"reset_type_allowed,omitempty"`
+	Status              string             `json:"status,omitempty" yaml:"status,omitempty"`
+	Path                string             `json:"path,omitempty" yaml:"path,omitempty"`
+	EscalatedPowerState redfish.ResetType  `json:"escalatedPowerState,omitempty" yaml:"escalated_power_state,omitempty"`
+	FinalPowerState     redfish.PowerState `json:"finalPowerState,omitempty" yaml:"final_power_state,omitempty"`
+	Elapsed             string             `json:"elapsed,omitempty" yaml:"elapsed,omitempty"`
 	Error               error              `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
@@ -65,7 +86,10 @@ type State struct {
 	Error      error              `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
-// Issue issues an action against a host.
+// Issue issues an action against a host, unless the host is already in the state the action results in and
+// `--always` was not given. If `--escalate-after` was given, graceful actions are escalated to their
+// forceful counterpart when the host has not begun to transition by the deadline. If `--wait` was given, Issue waits
+// for the host to reach the power state expected after the (last) action.
 func Issue(host string, action interface{}) interface{} {
 	sc := StateChange{}
 	c, err := auth.Connection(host)
@@ -84,10 +108,130 @@ func Issue(host string, action interface{}) interface{} {
 	}
 	sc.PreviousPowerState = systems[0].PowerState
 	sc.RequestedPowerState = action.(redfish.ResetType)
+	sc.ResetTypeAllowed = resetTypeAllowed(
+		systems[0].SupportedResetTypes,
+		sc.RequestedPowerState,
+	)
+	if sc.ResetTypeAllowed == "no" {
+		sc.Error = fmt.Errorf(
+			"%s is not an allowable reset type, the host allows: %v",
+			sc.RequestedPowerState,
+			systems[0].SupportedResetTypes,
+		)
+		return sc
+	}
+
+	if !viper.GetBool("always") && noop(sc.PreviousPowerState, sc.RequestedPowerState) {
+		sc.Status = Unchanged
+		if wait.Timeout() > 0 {
+			sc.FinalPowerState = sc.PreviousPowerState
+		}
+		return sc
+	}
+
+	start := time.Now()
 	err = systems[0].Reset(sc.RequestedPowerState)
 	if err != nil {
 		sc.Error = err
+		return sc
+	}
+	sc.Status = Issued
+
+	if after := viper.GetDuration("escalate-after"); after > 0 {
+		err = escalate(
+			systems[0],
+			&sc,
+			after,
+		)
+		if err != nil {
+			sc.Error = err
+			return sc
+		}
+	}
+
+	if timeout := wait.Timeout(); timeout > 0 {
+		resetType := sc.RequestedPowerState
+		if sc.EscalatedPowerState != "" {
+			resetType = sc.EscalatedPowerState
+		}
+		sc.FinalPowerState, err = waitForPowerState(
+			systems[0],
+			resetType,
+			timeout,
+		)
+		sc.Elapsed = time.Since(start).Round(time.Second).String()
+		if err != nil {
+			sc.Error = err
+		}
 	}
 
 	return sc
 }
+
+// escalations maps graceful reset types to their forceful counterparts.
+var escalations = map[redfish.ResetType]redfish.ResetType{
+	redfish.GracefulShutdownResetType: redfish.ForceOffResetType,
+	redfish.GracefulRestartResetType:  redfish.ForceRestartResetType,
+}
+
+// escalate waits for a system to begin transitioning after a graceful reset, and issues the forceful reset if it
+// has not by the deadline. The path taken is recorded in the StateChange.
+func escalate(system *redfish.ComputerSystem, sc *StateChange, after time.Duration) error {
+	force, ok := escalations[sc.RequestedPowerState]
+	if !ok {
+		return nil
+	}
+
+	_, err := waitForTransition(
+		system,
+		sc.RequestedPowerState,
+		after,
+	)
+	if err == nil {
+		sc.Path = "graceful"
+		return nil
+	}
+
+	sc.Path = "escalated"
+	sc.EscalatedPowerState = force
+	return system.Reset(force)
+}
+
+// resetTypeAllowed returns whether a reset action's supported reset types allow the given reset type; "yes", "no", or
+// "unknown" when the system or chassis does not advertise the reset types it allows.
+func resetTypeAllowed(supported []redfish.ResetType, resetType redfish.ResetType) string {
+	if len(supported) == 0 {
+		return "unknown"
+	}
+	for _, allowed := range supported {
+		if allowed == resetType {
+			return "yes"
+		}
+	}
+	return "no"
+}
+
+// noop returns whether a reset would leave a system in its current power state; e.g. On for a system that is on.
+// Restarts and power-button presses always change the system.
+func noop(current redfish.PowerState, resetType redfish.ResetType) bool {
+	if resetType == redfish.PushPowerButtonResetType {
+		return false
+	}
+	expected, restart, err := expectedPowerState(
+		current,
+		resetType,
+	)
+	if err != nil || restart {
+		return false
+	}
+	return current == expected
+}
+
+// addAlwaysFlag adds the `--always` flag to a power command.
+func addAlwaysFlag(c *cobra.Command) {
+	c.PersistentFlags().Bool(
+		"always",
+		false,
+		"Issue the request to hosts that are already in the requested power state",
+	)
+}
@@ -48,8 +48,11 @@ func NewPowerResetCommand() *cobra.Command {
 				redfish.ForceRestartResetType,
 			)
 			cli.PrettyPrint(content)
+			checkWait(content)
 		},
 		Hidden: false,
 	}
+	addWaitFlag(c)
+	set.AddFlags(c)
 	return c
 }
@@ -0,0 +1,228 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package power
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// Sources of power information.
+const (
+	PowerSource          = "Power"
+	PowerSubsystemSource = "PowerSubsystem"
+)
+
+// Power represents a chassis' power consumption, capacity, and limit. Source is the resource the readings came
+// from; the (deprecated) Power resource, or the PowerSubsystem and EnvironmentMetrics resources.
+type Power struct {
+	Chassis              string        `json:"chassis,omitempty" yaml:"chassis,omitempty"`
+	Source               string        `json:"source,omitempty" yaml:"source,omitempty"`
+	ConsumedWatts        float32       `json:"consumedWatts" yaml:"consumed_watts"`
+	AverageConsumedWatts float32       `json:"averageConsumedWatts,omitempty" yaml:"average_consumed_watts,omitempty"`
+	MaxConsumedWatts     float32       `json:"maxConsumedWatts,omitempty" yaml:"max_consumed_watts,omitempty"`
+	CapacityWatts        float32       `json:"capacityWatts,omitempty" yaml:"capacity_watts,omitempty"`
+	LimitWatts           float32       `json:"limitWatts,omitempty" yaml:"limit_watts,omitempty"`
+	LimitException       string        `json:"limitException,omitempty" yaml:"limit_exception,omitempty"`
+	PowerSupplies        []PowerSupply `json:"powerSupplies,omitempty" yaml:"power_supplies,omitempty"`
+	Error                error         `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// PowerSupply represents a single power supply.
+type PowerSupply struct {
+	Name             string  `json:"name" yaml:"name"`
+	Model            string  `json:"model" yaml:"model"`
+	Health           string  `json:"health" yaml:"health"`
+	State            string  `json:"state" yaml:"state"`
+	LineInputVoltage float32 `json:"lineInputVoltage" yaml:"line_input_voltage"`
+	FirmwareVersion  string  `json:"firmwareVersion" yaml:"firmware_version"`
+}
+
+// NewShowCommand creates the `power` subcommand for `show`.
+func NewShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "power host [...host]",
+		Short: "Power consumption and power supplies",
+		Long: `Show the Server's power consumption, capacity, and power limit (cap), and its power supplies with their
+health, input voltage, and firmware.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+			content := query.Async(
+				getPower,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	return c
+}
+
+func getPower(host string) interface{} {
+	p := Power{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		p.Error = err
+		return p
+	}
+	defer c.Logout()
+
+	chassis, power, subsystem, err := powerChassis(c.Service)
+	if err != nil {
+		p.Error = err
+		return p
+	}
+	p.Chassis = chassis.ID
+
+	if power != nil {
+		p.Source = PowerSource
+		if len(power.PowerControl) > 0 {
+			pc := power.PowerControl[0]
+			p.ConsumedWatts = pc.PowerConsumedWatts
+			p.AverageConsumedWatts = pc.PowerMetrics.AverageConsumedWatts
+			p.MaxConsumedWatts = pc.PowerMetrics.MaxConsumedWatts
+			p.CapacityWatts = pc.PowerCapacityWatts
+			p.LimitWatts = pc.PowerLimit.LimitInWatts
+			p.LimitException = string(pc.PowerLimit.LimitException)
+		}
+		for _, psu := range power.PowerSupplies {
+			p.PowerSupplies = append(
+				p.PowerSupplies,
+				PowerSupply{
+					Name:             psu.Name,
+					Model:            psu.Model,
+					Health:           string(psu.Status.Health),
+					State:            string(psu.Status.State),
+					LineInputVoltage: psu.LineInputVoltage,
+					FirmwareVersion:  psu.FirmwareVersion,
+				},
+			)
+		}
+		return p
+	}
+
+	p.Source = PowerSubsystemSource
+	p.CapacityWatts = float32(subsystem.CapacityWatts)
+	metrics, err := chassis.EnvironmentMetrics()
+	if err != nil {
+		p.Error = err
+		return p
+	}
+	if metrics != nil {
+		p.ConsumedWatts = metrics.PowerWatts.Reading
+		if metrics.PowerLimitWatts.ControlMode != redfish.DisabledControlMode {
+			p.LimitWatts = float32(metrics.PowerLimitWatts.SetPoint)
+		}
+	}
+
+	p.PowerSupplies, err = getPowerSupplyUnits(subsystem)
+	if err != nil {
+		p.Error = err
+	}
+	return p
+}
+
+// powerChassis returns the first chassis with power information, and either its Power or its PowerSubsystem.
+func powerChassis(service *gofish.Service) (*redfish.Chassis, *redfish.Power, *redfish.PowerSubsystem, error) {
+	chassis, err := service.Chassis()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, ch := range chassis {
+		power, err := ch.Power()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if power != nil {
+			return ch, power, nil, nil
+		}
+		subsystem, err := ch.PowerSubsystem()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if subsystem != nil {
+			return ch, nil, subsystem, nil
+		}
+	}
+	return nil, nil, nil, fmt.Errorf("no chassis with power information found")
+}
+
+// getPowerSupplyUnits returns a PowerSubsystem's power supplies, with their input voltage from their metrics.
+func getPowerSupplyUnits(subsystem *redfish.PowerSubsystem) ([]PowerSupply, error) {
+	resp, err := subsystem.GetClient().Get(subsystem.ODataID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var raw struct {
+		PowerSupplies common.Link
+	}
+	err = json.NewDecoder(resp.Body).Decode(&raw)
+	if err != nil {
+		return nil, err
+	}
+	if raw.PowerSupplies == "" {
+		return nil, nil
+	}
+
+	units, err := common.GetCollectionObjects[redfish.PowerSupplyUnit](
+		subsystem.GetClient(),
+		raw.PowerSupplies.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var supplies []PowerSupply
+	for _, unit := range units {
+		supply := PowerSupply{
+			Name:            unit.Name,
+			Model:           unit.Model,
+			Health:          string(unit.Status.Health),
+			State:           string(unit.Status.State),
+			FirmwareVersion: unit.FirmwareVersion,
+		}
+		metrics, err := unit.Metrics()
+		if err == nil && metrics != nil {
+			supply.LineInputVoltage = metrics.InputVoltage.Reading
+		}
+		supplies = append(
+			supplies,
+			supply,
+		)
+	}
+	return supplies, nil
+}
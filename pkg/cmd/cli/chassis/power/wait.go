@@ -0,0 +1,209 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package power
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/wait"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+)
+
+// addWaitFlag adds the `--wait` and `--timeout` flags to a power command.
+func addWaitFlag(c *cobra.Command) {
+	wait.AddFlags(
+		c,
+		"the expected power state to be reached",
+	)
+}
+
+// checkWait exits non-zero if `--wait` was given and any host failed to reach its expected power state.
+func checkWait(content map[string]interface{}) {
+	cmd.CheckError(
+		wait.Check(
+			content,
+			"host",
+			"did not reach the expected power state",
+		),
+	)
+}
+
+// expectedPowerState returns the power state a system reaches after a reset, and whether the system must be seen
+// restarting before that state counts.
+func expectedPowerState(previous redfish.PowerState, resetType redfish.ResetType) (redfish.PowerState, bool, error) {
+	switch resetType {
+	case redfish.OnResetType, redfish.ForceOnResetType:
+		return redfish.OnPowerState, false, nil
+	case redfish.ForceOffResetType, redfish.GracefulShutdownResetType:
+		return redfish.OffPowerState, false, nil
+	case redfish.GracefulRestartResetType, redfish.ForceRestartResetType, redfish.PowerCycleResetType:
+		return redfish.OnPowerState, true, nil
+	case redfish.PushPowerButtonResetType:
+		if previous == redfish.OnPowerState {
+			return redfish.OffPowerState, false, nil
+		}
+		return redfish.OnPowerState, false, nil
+	}
+	return "", false, fmt.Errorf(
+		"can not wait for the result of %s",
+		resetType,
+	)
+}
+
+// waitForPowerState polls a system until it reaches the power state expected after the given reset, or until the
+// timeout. For restarts the system must also be seen restarting; either powered off, or its BootProgress updated
+// (e.g. POST started) since the reset was issued. Returns the last power state seen.
+func waitForPowerState(system *redfish.ComputerSystem, resetType redfish.ResetType, timeout time.Duration) (redfish.PowerState, error) {
+	return watchPowerState(
+		watchedSystem(system),
+		resetType,
+		timeout,
+		false,
+	)
+}
+
+// waitForTransition is like waitForPowerState, however for restarts it returns as soon as the system is seen
+// restarting instead of waiting for it to power back on.
+func waitForTransition(system *redfish.ComputerSystem, resetType redfish.ResetType, timeout time.Duration) (redfish.PowerState, error) {
+	return watchPowerState(
+		watchedSystem(system),
+		resetType,
+		timeout,
+		true,
+	)
+}
+
+// watched is a system or chassis whose power state is polled for the result of a reset.
+type watched struct {
+	// Kind names the resource in errors; system or chassis.
+	Kind string
+	// PowerState and LastBoot are the resource's state when the reset was issued. LastBoot is the time of its last
+	// BootProgress update, which chassis do not have.
+	PowerState redfish.PowerState
+	LastBoot   string
+	// Read returns the resource's current power state and last BootProgress update.
+	Read func() (redfish.PowerState, string, error)
+}
+
+// watchedSystem returns a system to watch with watchPowerState.
+func watchedSystem(system *redfish.ComputerSystem) watched {
+	return watched{
+		Kind:       "system",
+		PowerState: system.PowerState,
+		LastBoot:   system.BootProgress.LastStateTime,
+		Read: func() (redfish.PowerState, string, error) {
+			current, err := redfish.GetComputerSystem(
+				system.GetClient(),
+				system.ODataID,
+			)
+			if err != nil {
+				return "", "", err
+			}
+			return current.PowerState, current.BootProgress.LastStateTime, nil
+		},
+	}
+}
+
+// watchedChassis returns a chassis to watch with watchPowerState.
+func watchedChassis(chassis *redfish.Chassis) watched {
+	return watched{
+		Kind:       "chassis",
+		PowerState: chassis.PowerState,
+		Read: func() (redfish.PowerState, string, error) {
+			current, err := redfish.GetChassis(
+				chassis.GetClient(),
+				chassis.ODataID,
+			)
+			if err != nil {
+				return "", "", err
+			}
+			return current.PowerState, "", nil
+		},
+	}
+}
+
+// watchPowerState polls a system or chassis for the result of a reset, see waitForPowerState and waitForTransition.
+func watchPowerState(w watched, resetType redfish.ResetType, timeout time.Duration, transition bool) (redfish.PowerState, error) {
+	expected, restart, err := expectedPowerState(
+		w.PowerState,
+		resetType,
+	)
+	if err != nil {
+		return w.PowerState, err
+	}
+
+	lastState := w.PowerState
+	restarted := false
+	deadline := time.Now().Add(timeout)
+
+	for {
+		interval := wait.PollInterval
+		if remaining := time.Until(deadline); remaining < interval {
+			interval = remaining
+		}
+		if interval > 0 {
+			time.Sleep(interval)
+		}
+
+		state, boot, err := w.Read()
+		// The BMC may briefly stop answering while the resource resets, keep polling until the deadline.
+		if err == nil {
+			lastState = state
+			if state == redfish.OffPowerState || state == redfish.PoweringOffPowerState {
+				restarted = true
+			}
+			if boot != "" && boot != w.LastBoot {
+				restarted = true
+			}
+			if state == expected && (!restart || restarted) {
+				return lastState, nil
+			}
+			if transition && restart && restarted {
+				return lastState, nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			if restart && !restarted {
+				return lastState, fmt.Errorf(
+					"timed out after %s waiting for the %s to restart",
+					timeout,
+					w.Kind,
+				)
+			}
+			return lastState, fmt.Errorf(
+				"timed out after %s waiting for %s",
+				timeout,
+				expected,
+			)
+		}
+	}
+}
@@ -0,0 +1,324 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package firmware
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Cray-HPE/gru/internal/glob"
+	"github.com/Cray-HPE/gru/internal/natural"
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// Compliance states of a host or component.
+const (
+	Current  = "current"
+	Outdated = "outdated"
+	Unknown  = "unknown"
+)
+
+// Baseline is the firmware each platform requires.
+type Baseline struct {
+	Platforms []Platform `yaml:"platforms"`
+}
+
+// Platform maps a manufacturer and model, both patterns where * and ? are wildcards, to the version range required
+// of each component matching a pattern.
+type Platform struct {
+	Manufacturer string            `yaml:"manufacturer"`
+	Model        string            `yaml:"model"`
+	Components   map[string]string `yaml:"components"`
+	ranges       map[string]versionRange
+}
+
+// Compliance represents a host's compliance with the baseline. A host is outdated if any component is, unknown if
+// its platform is not in the baseline or a component's version can not be found, and otherwise current.
+type Compliance struct {
+	Manufacturer string                `json:"manufacturer" yaml:"manufacturer"`
+	Model        string                `json:"model" yaml:"model"`
+	Status       string                `json:"status" yaml:"status"`
+	Components   []ComponentCompliance `json:"components,omitempty" yaml:"components,omitempty"`
+	Error        error                 `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ComponentCompliance represents a single component's compliance with the baseline.
+type ComponentCompliance struct {
+	Component string `json:"component" yaml:"component"`
+	Name      string `json:"name" yaml:"name"`
+	Version   string `json:"version" yaml:"version"`
+	Required  string `json:"required" yaml:"required"`
+	Status    string `json:"status" yaml:"status"`
+}
+
+// NewCheckCommand creates the `check` subcommand for `firmware`.
+func NewCheckCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "check host [...host]",
+		Short: "Check firmware against a baseline",
+		Long: `Check each host's firmware inventory against a baseline and report every component as current, outdated,
+or unknown (not in the inventory). Exits non-zero unless every host is current.
+
+The baseline is YAML, a host uses the first platform matching its manufacturer and model. Components are patterns
+matching a component's name or ID, and require a version range; constraints (=, !=, <, <=, >, >=) separated by
+commas, with alternatives separated by ||.
+
+  platforms:
+    - manufacturer: HPE
+      model: "ProLiant DL385*"
+      components:
+        "*iLO*": ">=2.72"
+        "*System ROM*": "A42 v2.80 || A42 v2.90"
+        "*Mellanox*": ">=20.31, <21"`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			file := v.GetString("baseline")
+			if file == "" {
+				cmd.CheckError(fmt.Errorf("no baseline given"))
+			}
+			baseline, err := readBaseline(file)
+			cmd.CheckError(err)
+
+			content := query.Async(
+				func(host string) interface{} {
+					return checkFirmware(
+						host,
+						baseline,
+					)
+				},
+				hosts,
+			)
+			cli.PrettyPrint(content)
+
+			var failed []string
+			for host, result := range content {
+				if result.(Compliance).Status != Current {
+					failed = append(
+						failed,
+						host,
+					)
+				}
+			}
+			if len(failed) > 0 {
+				sort.Strings(failed)
+				cmd.CheckError(
+					fmt.Errorf(
+						"%d host(s) not compliant: %s",
+						len(failed),
+						strings.Join(
+							failed,
+							" ",
+						),
+					),
+				)
+			}
+		},
+	}
+	c.PersistentFlags().String(
+		"baseline",
+		"",
+		"YAML file of the firmware versions each platform requires",
+	)
+	return c
+}
+
+// readBaseline reads a YAML baseline and parses its version ranges.
+func readBaseline(file string) (Baseline, error) {
+	baseline := Baseline{}
+	if !regexp.MustCompile(`ya?ml`).MatchString(filepath.Ext(file)) {
+		return baseline, fmt.Errorf(
+			"invalid filetype: %s",
+			filepath.Ext(file),
+		)
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return baseline, err
+	}
+	err = yaml.Unmarshal(
+		b,
+		&baseline,
+	)
+	if err != nil {
+		return baseline, err
+	}
+	if len(baseline.Platforms) == 0 {
+		return baseline, fmt.Errorf(
+			"no platforms in baseline %s",
+			file,
+		)
+	}
+
+	for i := range baseline.Platforms {
+		p := &baseline.Platforms[i]
+		p.ranges = make(map[string]versionRange)
+		for pattern, text := range p.Components {
+			r, err := parseRange(text)
+			if err != nil {
+				return baseline, fmt.Errorf(
+					"%s %s %s: %v",
+					p.Manufacturer,
+					p.Model,
+					pattern,
+					err,
+				)
+			}
+			p.ranges[pattern] = r
+		}
+	}
+	return baseline, nil
+}
+
+// platform returns the first platform matching a manufacturer and model.
+func (b Baseline) platform(manufacturer string, model string) *Platform {
+	for i, p := range b.Platforms {
+		if glob.Match(p.Manufacturer, manufacturer) && glob.Match(p.Model, model) {
+			return &b.Platforms[i]
+		}
+	}
+	return nil
+}
+
+func checkFirmware(host string, baseline Baseline) Compliance {
+	compliance := Compliance{Status: Unknown}
+	c, err := auth.Connection(host)
+	if err != nil {
+		compliance.Error = err
+		return compliance
+	}
+	defer c.Logout()
+
+	systems, err := c.Service.Systems()
+	if err != nil || len(systems) < 1 {
+		compliance.Error = err
+		return compliance
+	}
+	compliance.Manufacturer = strings.TrimSpace(systems[0].Manufacturer)
+	compliance.Model = strings.TrimSpace(systems[0].Model)
+	platform := baseline.platform(
+		compliance.Manufacturer,
+		compliance.Model,
+	)
+	if platform == nil {
+		compliance.Error = fmt.Errorf("platform is not in the baseline")
+		return compliance
+	}
+
+	components, err := getInventory(
+		c,
+		c.Service.ODataID,
+	)
+	if err != nil {
+		compliance.Error = err
+		return compliance
+	}
+
+	patterns := make(
+		[]string,
+		0,
+		len(platform.ranges),
+	)
+	for pattern := range platform.ranges {
+		patterns = append(
+			patterns,
+			pattern,
+		)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		return natural.Less(
+			patterns[i],
+			patterns[j],
+		)
+	})
+
+	outdated, unknown := false, false
+	for _, pattern := range patterns {
+		required := platform.ranges[pattern]
+		found := false
+		for _, component := range components {
+			if !matches(pattern, component) {
+				continue
+			}
+			found = true
+			status := Current
+			if component.Version == "" {
+				status = Unknown
+				unknown = true
+			} else if !required.contains(component.Version) {
+				status = Outdated
+				outdated = true
+			}
+			compliance.Components = append(
+				compliance.Components,
+				ComponentCompliance{
+					Component: component.ID,
+					Name:      component.Name,
+					Version:   component.Version,
+					Required:  required.String(),
+					Status:    status,
+				},
+			)
+		}
+		if !found {
+			unknown = true
+			compliance.Components = append(
+				compliance.Components,
+				ComponentCompliance{
+					Component: pattern,
+					Required:  required.String(),
+					Status:    Unknown,
+				},
+			)
+		}
+	}
+
+	switch {
+	case outdated:
+		compliance.Status = Outdated
+	case unknown:
+		compliance.Status = Unknown
+	default:
+		compliance.Status = Current
+	}
+	return compliance
+}
@@ -0,0 +1,180 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package firmware
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish/common"
+
+	"github.com/Cray-HPE/gru/internal/glob"
+	"github.com/Cray-HPE/gru/internal/natural"
+	"github.com/Cray-HPE/gru/internal/query"
+)
+
+// NewCommand creates the `firmware` subcommand.
+func NewCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "firmware",
+		Short: "Firmware compliance and updates",
+		Long:  `Check and update the firmware of one or more hosts`,
+	}
+	c.AddCommand(
+		NewCheckCommand(),
+		NewUpdateCommand(),
+	)
+	return c
+}
+
+// Firmware represents a host's firmware inventory.
+type Firmware struct {
+	Components []Component `json:"components" yaml:"components"`
+	Error      error       `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Component represents a single item of the firmware inventory (e.g. BMC, BIOS, CPLD, NIC, drive, or PSU firmware).
+// RelatedItem is a comma delimited list of the resources the firmware belongs to.
+type Component struct {
+	ID           string `json:"id" yaml:"id"`
+	Name         string `json:"name" yaml:"name"`
+	Version      string `json:"version" yaml:"version"`
+	Manufacturer string `json:"manufacturer" yaml:"manufacturer"`
+	Updateable   bool   `json:"updateable" yaml:"updateable"`
+	ReleaseDate  string `json:"releaseDate" yaml:"release_date"`
+	RelatedItem  string `json:"relatedItem" yaml:"related_item"`
+	URI          string `json:"uri" yaml:"uri"`
+}
+
+type rawInventory struct {
+	ODataID      string `json:"@odata.id"`
+	ID           string `json:"Id"`
+	Name         string
+	Version      string
+	Manufacturer string
+	Updateable   bool
+	ReleaseDate  string
+	RelatedItem  common.Links
+}
+
+type rawUpdateService struct {
+	FirmwareInventory    common.Link
+	MultipartHTTPPushURI string `json:"MultipartHttpPushUri"`
+	Actions              struct {
+		SimpleUpdate struct {
+			Target            string
+			TransferProtocols []string `json:"TransferProtocol@Redfish.AllowableValues"`
+		} `json:"#UpdateService.SimpleUpdate"`
+	}
+}
+
+// getUpdateService returns the UpdateService of a service root.
+func getUpdateService(client common.Client, service string) (rawUpdateService, error) {
+	var update rawUpdateService
+	var raw struct {
+		UpdateService common.Link
+	}
+	err := query.GetJSON(
+		client,
+		service,
+		&raw,
+	)
+	if err != nil {
+		return update, err
+	}
+	if raw.UpdateService == "" {
+		return update, fmt.Errorf("no UpdateService")
+	}
+	err = query.GetJSON(
+		client,
+		raw.UpdateService.String(),
+		&update,
+	)
+	return update, err
+}
+
+// getInventory returns every component of the firmware inventory, sorted by ID.
+func getInventory(client common.Client, service string) ([]Component, error) {
+	update, err := getUpdateService(
+		client,
+		service,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if update.FirmwareInventory == "" {
+		return nil, fmt.Errorf("no FirmwareInventory")
+	}
+	inventory, err := query.GetMembers[rawInventory](
+		client,
+		update.FirmwareInventory.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	components := make(
+		[]Component,
+		0,
+		len(inventory),
+	)
+	for _, i := range inventory {
+		components = append(
+			components,
+			Component{
+				ID:           i.ID,
+				Name:         strings.TrimSpace(i.Name),
+				Version:      strings.TrimSpace(i.Version),
+				Manufacturer: strings.TrimSpace(i.Manufacturer),
+				Updateable:   i.Updateable,
+				ReleaseDate:  i.ReleaseDate,
+				RelatedItem: strings.Join(
+					i.RelatedItem.ToStrings(),
+					", ",
+				),
+				URI: i.ODataID,
+			},
+		)
+	}
+	sort.SliceStable(components, func(i, j int) bool {
+		return natural.Less(
+			components[i].ID,
+			components[j].ID,
+		)
+	})
+	return components, nil
+}
+
+// matches reports whether a component's name or ID matches a pattern, an empty pattern matches every component.
+func matches(pattern string, c Component) bool {
+	if pattern == "" {
+		return true
+	}
+	return glob.Match(pattern, c.Name) || glob.Match(pattern, c.ID)
+}
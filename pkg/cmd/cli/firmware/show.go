@@ -0,0 +1,102 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package firmware
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewShowCommand creates the `firmware` subcommand for `show`.
+func NewShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "firmware host [...host]",
+		Short: "Firmware inventory",
+		Long: `Show every component of the Server's firmware inventory (BMC, BIOS, CPLD, NICs, drives, PSUs, etc.) with its
+version, whether it is updateable, its release date, and the resources it belongs to.
+
+--component only shows the components whose name or ID match a pattern, where * and ? are wildcards.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := query.Async(
+				getFirmware,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	addComponentFlag(c)
+	return c
+}
+
+// addComponentFlag adds the `--component` flag to a command.
+func addComponentFlag(c *cobra.Command) {
+	c.PersistentFlags().String(
+		"component",
+		"",
+		"Only include components whose name or ID match a pattern (e.g. \"*BIOS*\")",
+	)
+}
+
+func getFirmware(host string) interface{} {
+	f := Firmware{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		f.Error = err
+		return f
+	}
+	defer c.Logout()
+
+	components, err := getInventory(
+		c,
+		c.Service.ODataID,
+	)
+	if err != nil {
+		f.Error = err
+		return f
+	}
+	pattern := viper.GetString("component")
+	for _, component := range components {
+		if matches(pattern, component) {
+			f.Components = append(
+				f.Components,
+				component,
+			)
+		}
+	}
+	return f
+}
@@ -0,0 +1,589 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package firmware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish"
+
+	"github.com/Cray-HPE/gru/internal/serve"
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/internal/task"
+	"github.com/Cray-HPE/gru/internal/wait"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// States of a FirmwareUpdate.
+const (
+	DryRun  = "dry-run"
+	Staged  = "staged"
+	Updated = "updated"
+)
+
+// Methods of a FirmwareUpdate.
+const (
+	SimpleUpdate  = "SimpleUpdate"
+	MultipartPush = "MultipartHttpPush"
+)
+
+// applyTimes are the values of --apply-time, an update applied at any time but Immediate is staged.
+var applyTimes = []string{
+	"Immediate",
+	"OnReset",
+	"AtMaintenanceWindowStart",
+	"InMaintenanceWindowOnReset",
+	"OnStartUpdateRequest",
+}
+
+// FirmwareUpdate represents a firmware update of a host. Targets are the IDs of the components updated, Before and
+// After their versions before and after the update (or of every component that changed if no targets were given).
+type FirmwareUpdate struct {
+	Method   string `json:"method" yaml:"method"`
+	ImageURI string `json:"imageURI,omitempty" yaml:"image_uri,omitempty"`
+	Targets  string `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Task     string `json:"task,omitempty" yaml:"task,omitempty"`
+	Before   string `json:"before,omitempty" yaml:"before,omitempty"`
+	After    string `json:"after,omitempty" yaml:"after,omitempty"`
+	State    string `json:"state,omitempty" yaml:"state,omitempty"`
+	Error    error  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// update is the firmware update requested of every host.
+type update struct {
+	image     string
+	server    *serve.Server
+	targets   []string
+	version   string
+	applyTime string
+}
+
+// NewUpdateCommand creates the `update` subcommand for `firmware`.
+func NewUpdateCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "update host [...host]",
+		Short: "Update firmware",
+		Long: `Update firmware with an image from a URL or a local file, and wait for the update's task. An image URL is
+passed to the BMC's SimpleUpdate action. A local file is pushed to the BMC (MultipartHttpPushUri), or with --serve,
+served from a temporary HTTP server for the BMC's SimpleUpdate action.
+
+--targets are patterns matching the name or ID of the components to update, otherwise the BMC chooses from the image.
+Once an update applied immediately completes, the firmware inventory must report --version for every target (or
+without --version, a new version). The apply time is always sent, since a BMC's own default may stage the update;
+updates applied at a later --apply-time are staged and not verified.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			u := update{
+				image:     v.GetString("image"),
+				targets:   v.GetStringSlice("targets"),
+				version:   v.GetString("version"),
+				applyTime: v.GetString("apply-time"),
+			}
+			if u.image == "" {
+				cmd.CheckError(fmt.Errorf("no image given"))
+			}
+			if !validApplyTime(u.applyTime) {
+				cmd.CheckError(
+					fmt.Errorf(
+						"invalid apply time %q, must be one of: %s",
+						u.applyTime,
+						strings.Join(
+							applyTimes,
+							", ",
+						),
+					),
+				)
+			}
+			if remote(u.image) {
+				if v.GetBool("serve") {
+					cmd.CheckError(fmt.Errorf("--serve requires a local image"))
+				}
+			} else {
+				_, err := os.Stat(u.image)
+				cmd.CheckError(err)
+			}
+			if v.GetBool("serve") && !v.GetBool("dry-run") {
+				server, err := serve.Start(
+					u.image,
+					v.GetInt("serve-port"),
+				)
+				cmd.CheckError(err)
+				defer server.Close()
+				u.server = server
+			}
+
+			content := set.Async(
+				updateFirmware,
+				hosts,
+				u,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	c.PersistentFlags().String(
+		"image",
+		"",
+		"Firmware image; a URL, or a local file",
+	)
+	c.PersistentFlags().StringSlice(
+		"targets",
+		[]string{},
+		"Components to update, patterns matching their name or ID (e.g. BIOS,\"*Mellanox*\")",
+	)
+	c.PersistentFlags().String(
+		"version",
+		"",
+		"Version every target must report after the update",
+	)
+	c.PersistentFlags().String(
+		"apply-time",
+		"Immediate",
+		fmt.Sprintf(
+			"When to apply the update; %s",
+			strings.Join(
+				applyTimes,
+				", ",
+			),
+		),
+	)
+	c.PersistentFlags().Bool(
+		"serve",
+		false,
+		"Serve the local image from a temporary HTTP server for SimpleUpdate, instead of pushing it",
+	)
+	c.PersistentFlags().Int(
+		"serve-port",
+		0,
+		"Port of the temporary HTTP server, 0 picks a free port",
+	)
+	c.PersistentFlags().Bool(
+		"dry-run",
+		false,
+		"Only show the method and targets that would be used on each host",
+	)
+	c.PersistentFlags().Duration(
+		"timeout",
+		time.Hour,
+		"Maximum time to wait for each update to complete and be verified",
+	)
+	set.AddFlags(c)
+	return c
+}
+
+func validApplyTime(applyTime string) bool {
+	for _, a := range applyTimes {
+		if a == applyTime {
+			return true
+		}
+	}
+	return false
+}
+
+// remote returns whether an image is a URL.
+func remote(image string) bool {
+	return strings.Contains(
+		image,
+		"://",
+	)
+}
+
+func updateFirmware(host string, data any) interface{} {
+	u := data.(update)
+	fu := FirmwareUpdate{}
+	deadline := time.Now().Add(viper.GetDuration("timeout"))
+	c, err := auth.Connection(host)
+	if err != nil {
+		fu.Error = err
+		return fu
+	}
+	defer c.Logout()
+
+	service, err := getUpdateService(
+		c,
+		c.Service.ODataID,
+	)
+	if err != nil {
+		fu.Error = err
+		return fu
+	}
+	components, err := getInventory(
+		c,
+		c.Service.ODataID,
+	)
+	if err != nil {
+		fu.Error = err
+		return fu
+	}
+	targets, err := selectTargets(
+		components,
+		u.targets,
+	)
+	if err != nil {
+		fu.Error = err
+		return fu
+	}
+	var ids []string
+	for _, t := range targets {
+		ids = append(
+			ids,
+			t.ID,
+		)
+	}
+	fu.Targets = strings.Join(
+		ids,
+		", ",
+	)
+
+	switch {
+	case remote(u.image):
+		fu.Method = SimpleUpdate
+		fu.ImageURI = u.image
+	case viper.GetBool("serve"):
+		fu.Method = SimpleUpdate
+		if u.server != nil {
+			fu.ImageURI, err = u.server.URL(host)
+			if err != nil {
+				fu.Error = err
+				return fu
+			}
+		}
+	default:
+		fu.Method = MultipartPush
+	}
+	if fu.Method == SimpleUpdate && service.Actions.SimpleUpdate.Target == "" {
+		fu.Error = fmt.Errorf("BMC does not support SimpleUpdate")
+		return fu
+	}
+	if fu.Method == MultipartPush && service.MultipartHTTPPushURI == "" {
+		fu.Error = fmt.Errorf("BMC does not support multipart HTTP push, serve the image with --serve instead")
+		return fu
+	}
+	if viper.GetBool("dry-run") {
+		fu.State = DryRun
+		return fu
+	}
+
+	var resp *http.Response
+	if fu.Method == SimpleUpdate {
+		resp, err = simpleUpdate(
+			c,
+			service,
+			fu.ImageURI,
+			targets,
+			u.applyTime,
+		)
+	} else {
+		resp, err = multipartPush(
+			c,
+			service,
+			u.image,
+			targets,
+			u.applyTime,
+		)
+	}
+	if err != nil {
+		fu.Error = err
+		return fu
+	}
+	defer resp.Body.Close()
+
+	fu.Task, err = task.Follow(
+		c,
+		resp,
+		time.Until(deadline),
+		func(s task.Status) {
+			fmt.Fprintf(
+				os.Stderr,
+				"%s: update %s %d%%\n",
+				host,
+				s.State,
+				s.PercentComplete,
+			)
+		},
+	)
+	if err != nil {
+		fu.Error = err
+		return fu
+	}
+	if u.applyTime != "Immediate" {
+		fu.State = Staged
+		return fu
+	}
+
+	fu.Before, fu.After, err = verify(
+		host,
+		components,
+		targets,
+		u.version,
+		deadline,
+	)
+	if err != nil {
+		fu.Error = err
+		return fu
+	}
+	fu.State = Updated
+	return fu
+}
+
+// selectTargets returns the components matching any of the patterns, every pattern must match a component.
+func selectTargets(components []Component, patterns []string) ([]Component, error) {
+	var targets []Component
+	for _, pattern := range patterns {
+		found := false
+		for _, c := range components {
+			if matches(pattern, c) {
+				found = true
+				targets = append(
+					targets,
+					c,
+				)
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf(
+				"no component matching %q",
+				pattern,
+			)
+		}
+	}
+	return targets, nil
+}
+
+// targetLinks returns the links of the components to update.
+func targetLinks(targets []Component) []string {
+	links := []string{}
+	for _, t := range targets {
+		links = append(
+			links,
+			t.URI,
+		)
+	}
+	return links
+}
+
+func simpleUpdate(c *gofish.APIClient, service rawUpdateService, imageURI string, targets []Component, applyTime string) (*http.Response, error) {
+	u, err := url.Parse(imageURI)
+	if err != nil {
+		return nil, err
+	}
+	protocol := strings.ToUpper(u.Scheme)
+	if allowed := service.Actions.SimpleUpdate.TransferProtocols; len(allowed) > 0 {
+		supported := false
+		for _, a := range allowed {
+			supported = supported || strings.EqualFold(a, protocol)
+		}
+		if !supported {
+			return nil, fmt.Errorf(
+				"BMC does not support %s transfers, only: %s",
+				protocol,
+				strings.Join(
+					allowed,
+					", ",
+				),
+			)
+		}
+	}
+
+	payload := map[string]interface{}{
+		"ImageURI":         imageURI,
+		"TransferProtocol": protocol,
+	}
+	if len(targets) > 0 {
+		payload["Targets"] = targetLinks(targets)
+	}
+	payload["@Redfish.OperationApplyTime"] = applyTime
+	return c.Post(
+		service.Actions.SimpleUpdate.Target,
+		payload,
+	)
+}
+
+func multipartPush(c *gofish.APIClient, service rawUpdateService, image string, targets []Component, applyTime string) (*http.Response, error) {
+	parameters := map[string]interface{}{
+		"@Redfish.OperationApplyTime": applyTime,
+	}
+	if len(targets) > 0 {
+		parameters["Targets"] = targetLinks(targets)
+	}
+	b, err := json.Marshal(parameters)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(image)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return c.PostMultipart(
+		service.MultipartHTTPPushURI,
+		map[string]io.Reader{
+			"UpdateParameters": bytes.NewReader(b),
+			"UpdateFile":       f,
+		},
+	)
+}
+
+// verify reads the firmware inventory until every target reports the expected version, or without one a new
+// version. Without targets, any component must report it. The BMC may restart to apply its own firmware, so the
+// inventory is read with a new connection and failures are retried until the deadline. Returns the versions before
+// and after the update.
+func verify(host string, before []Component, targets []Component, expected string, deadline time.Time) (string, string, error) {
+	for {
+		components, err := inventory(host)
+		if err == nil {
+			var updated bool
+			var changed []Component
+			updated, changed, err = updatedComponents(
+				before,
+				components,
+				targets,
+				expected,
+			)
+			if updated {
+				b, a := versions(before, changed), versions(components, changed)
+				return b, a, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return "", "", err
+		}
+		time.Sleep(min(wait.PollInterval, time.Until(deadline)))
+	}
+}
+
+// inventory returns the firmware inventory of a host with a new connection.
+func inventory(host string) ([]Component, error) {
+	c, err := auth.Connection(host)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Logout()
+	return getInventory(
+		c,
+		c.Service.ODataID,
+	)
+}
+
+// updatedComponents returns whether the update is verified, and the components whose version changed. An error
+// describes why the update is not verified.
+func updatedComponents(before []Component, after []Component, targets []Component, expected string) (bool, []Component, error) {
+	previous := make(map[string]string)
+	for _, c := range before {
+		previous[c.ID] = c.Version
+	}
+	current := make(map[string]Component)
+	var changed []Component
+	for _, c := range after {
+		current[c.ID] = c
+		if v, ok := previous[c.ID]; ok && v != c.Version {
+			changed = append(
+				changed,
+				c,
+			)
+		}
+	}
+
+	if len(targets) == 0 {
+		for _, c := range changed {
+			if expected == "" || compareVersions(c.Version, expected) == 0 {
+				return true, changed, nil
+			}
+		}
+		if expected != "" {
+			return false, changed, fmt.Errorf(
+				"no component reports version %s",
+				expected,
+			)
+		}
+		return false, changed, fmt.Errorf("no component reports a new version")
+	}
+
+	for _, t := range targets {
+		c, ok := current[t.ID]
+		switch {
+		case !ok:
+			return false, targets, fmt.Errorf(
+				"%s is no longer in the firmware inventory",
+				t.ID,
+			)
+		case expected != "" && compareVersions(c.Version, expected) != 0:
+			return false, targets, fmt.Errorf(
+				"%s reports version %s, not %s",
+				t.ID,
+				c.Version,
+				expected,
+			)
+		case expected == "" && c.Version == t.Version:
+			return false, targets, fmt.Errorf(
+				"%s still reports version %s",
+				t.ID,
+				c.Version,
+			)
+		}
+	}
+	return true, targets, nil
+}
+
+// versions returns the versions of the given components as listed in an inventory, e.g. "BIOS=1.0, BMC=2.10.3".
+func versions(inventory []Component, components []Component) string {
+	version := make(map[string]string)
+	for _, c := range inventory {
+		version[c.ID] = c.Version
+	}
+	var list []string
+	for _, c := range components {
+		list = append(
+			list,
+			fmt.Sprintf(
+				"%s=%s",
+				c.ID,
+				version[c.ID],
+			),
+		)
+	}
+	return strings.Join(
+		list,
+		", ",
+	)
+}
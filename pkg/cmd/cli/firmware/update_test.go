@@ -0,0 +1,78 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package firmware
+
+import "testing"
+
+func TestUpdatedComponents(t *testing.T) {
+	before := []Component{
+		{ID: "BMC", Version: "2.71 Jun 10 2022"},
+		{ID: "BIOS", Version: "1.0"},
+	}
+	cases := []struct {
+		name     string
+		after    []Component
+		targets  []Component
+		expected string
+		want     bool
+	}{
+		{
+			name:     "target with a dated version",
+			after:    []Component{{ID: "BMC", Version: "2.72 Sep 04 2022"}, {ID: "BIOS", Version: "1.0"}},
+			targets:  []Component{before[0]},
+			expected: "2.72",
+			want:     true,
+		},
+		{
+			name:     "target with an older dated version",
+			after:    []Component{{ID: "BMC", Version: "2.71 Sep 04 2022"}, {ID: "BIOS", Version: "1.0"}},
+			targets:  []Component{before[0]},
+			expected: "2.72",
+			want:     false,
+		},
+		{
+			name:     "any component with a build",
+			after:    []Component{{ID: "BMC", Version: "2.71 Jun 10 2022"}, {ID: "BIOS", Version: "1.1 build 45"}},
+			expected: "1.1",
+			want:     true,
+		},
+		{
+			name:  "target unchanged",
+			after: before,
+			targets: []Component{
+				before[1],
+			},
+			want: false,
+		},
+	}
+	for _, c := range cases {
+		got, _, err := updatedComponents(before, c.after, c.targets, c.expected)
+		if got != c.want {
+			t.Errorf("%s: updated = %t, want %t (%v)", c.name, got, c.want, err)
+		}
+	}
+}
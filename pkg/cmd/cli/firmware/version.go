@@ -0,0 +1,215 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package firmware
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Cray-HPE/gru/internal/natural"
+)
+
+// constraint is a single comparison with a version, e.g. >=2.10.
+type constraint struct {
+	op      string
+	version string
+}
+
+// versionRange is a version requirement; alternatives separated by || of constraints separated by commas. Each
+// constraint is an operator (=, !=, <, <=, >, >=) and a version, a version without an operator must be equal,
+// e.g. ">=2.10, <3 || 3.1.4".
+type versionRange struct {
+	text         string
+	alternatives [][]constraint
+}
+
+var operators = []string{
+	">=",
+	"<=",
+	"!=",
+	">",
+	"<",
+	"=",
+}
+
+// parseRange parses a version requirement.
+func parseRange(text string) (versionRange, error) {
+	r := versionRange{text: strings.TrimSpace(text)}
+	for _, alternative := range strings.Split(text, "||") {
+		var constraints []constraint
+		for _, c := range strings.Split(alternative, ",") {
+			c = strings.TrimSpace(c)
+			if c == "" {
+				continue
+			}
+			op := "="
+			for _, o := range operators {
+				if strings.HasPrefix(c, o) {
+					op = o
+					c = strings.TrimSpace(strings.TrimPrefix(c, o))
+					break
+				}
+			}
+			if c == "" {
+				return r, fmt.Errorf(
+					"invalid version range %q: %s has no version",
+					text,
+					op,
+				)
+			}
+			constraints = append(
+				constraints,
+				constraint{
+					op:      op,
+					version: c,
+				},
+			)
+		}
+		if len(constraints) == 0 {
+			return r, fmt.Errorf(
+				"invalid version range %q",
+				text,
+			)
+		}
+		r.alternatives = append(
+			r.alternatives,
+			constraints,
+		)
+	}
+	return r, nil
+}
+
+// String returns the requirement as written.
+func (r versionRange) String() string {
+	return r.text
+}
+
+// contains reports whether a version satisfies the requirement.
+func (r versionRange) contains(version string) bool {
+	for _, alternative := range r.alternatives {
+		satisfied := true
+		for _, c := range alternative {
+			cmp := compareVersions(
+				version,
+				c.version,
+			)
+			switch c.op {
+			case "=":
+				satisfied = cmp == 0
+			case "!=":
+				satisfied = cmp != 0
+			case "<":
+				satisfied = cmp < 0
+			case "<=":
+				satisfied = cmp <= 0
+			case ">":
+				satisfied = cmp > 0
+			case ">=":
+				satisfied = cmp >= 0
+			}
+			if !satisfied {
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+// compareVersions compares two versions field by field, where fields are separated by any of ". -_" and numeric
+// fields compare numerically. A leading v is ignored and missing fields are 0, so 2.10 equals v2.10.0. Anything
+// after a numeric version, such as a date or build, is ignored, so "2.72 Sep 04 2022" equals 2.72.
+func compareVersions(a string, b string) int {
+	fa, fb := versionFields(a), versionFields(b)
+	for len(fa) < len(fb) {
+		fa = append(
+			fa,
+			"0",
+		)
+	}
+	for len(fb) < len(fa) {
+		fb = append(
+			fb,
+			"0",
+		)
+	}
+	for i := range fa {
+		na, errA := strconv.ParseUint(fa[i], 10, 64)
+		nb, errB := strconv.ParseUint(fb[i], 10, 64)
+		switch {
+		case errA == nil && errB == nil && na != nb:
+			if na < nb {
+				return -1
+			}
+			return 1
+		case errA == nil && errB == nil:
+			continue
+		case natural.Less(fa[i], fb[i]):
+			return -1
+		case natural.Less(fb[i], fa[i]):
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionFields splits a version into fields. A version that starts with a digit ends at its first space or at the
+// first field that does not start with a digit, which drops dates and builds such as "Sep 04 2022", "09/04/2022" or
+// "build 45". Versions that do not start with a digit, e.g. GDC5, keep all fields.
+func versionFields(version string) []string {
+	version = strings.TrimSpace(version)
+	version = strings.TrimPrefix(
+		strings.TrimPrefix(
+			version,
+			"v",
+		),
+		"V",
+	)
+	numeric := startsWithDigit(version)
+	if numeric {
+		version, _, _ = strings.Cut(version, " ")
+	}
+	fields := strings.FieldsFunc(version, func(r rune) bool {
+		return r == '.' || r == '-' || r == '_' || r == ' '
+	})
+	if !numeric {
+		return fields
+	}
+	for i, f := range fields {
+		if !startsWithDigit(f) {
+			return fields[:i]
+		}
+	}
+	return fields
+}
+
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
@@ -0,0 +1,78 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package firmware
+
+import "testing"
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{"2.10", "v2.10.0", 0},
+		{"2.10", "2.9", 1},
+		{"2.9", "2.10", -1},
+		{"2.72", "2.72.1", -1},
+		{"2.72 Sep 04 2022", "2.72", 0},
+		{"2.72 Sep 04 2022", "2.73", -1},
+		{"2.72 09/04/2022", "2.72", 0},
+		{"1.0 build 45", "1.0", 0},
+		{"1.0 (b45)", "1.1", -1},
+		{"2.10-rc1", "2.10", 0},
+		{"GDC5", "GDC5", 0},
+		{"GDC5", "GDC6", -1},
+	}
+	for _, c := range cases {
+		if got := compareVersions(c.a, c.b); got != c.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestVersionRangeContains(t *testing.T) {
+	cases := []struct {
+		text    string
+		version string
+		want    bool
+	}{
+		{">=2.72", "2.72 Sep 04 2022", true},
+		{">2.72", "2.72 Sep 04 2022", false},
+		{"=1.0", "1.0 build 45", true},
+		{">=2.10, <3 || 3.1.4", "3.1.4", true},
+		{">=2.10, <3 || 3.1.4", "3.0", false},
+	}
+	for _, c := range cases {
+		r, err := parseRange(c.text)
+		if err != nil {
+			t.Fatalf("parseRange(%q): %v", c.text, err)
+		}
+		if got := r.contains(c.version); got != c.want {
+			t.Errorf("%q contains %q = %t, want %t", c.text, c.version, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package logs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// Cleared is the state of a Clear that succeeded.
+const Cleared = "cleared"
+
+// Clear represents the clearing of the logs of a host. Logs is a comma delimited list of the IDs of the logs cleared.
+type Clear struct {
+	Logs  string `json:"logs,omitempty" yaml:"logs,omitempty"`
+	State string `json:"state,omitempty" yaml:"state,omitempty"`
+	Error error  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewClearCommand creates the `clear` subcommand for `logs`.
+func NewClearCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "clear host [...host]",
+		Short: "Clear logs",
+		Long: `Clear every log that can be cleared (or each --log) with the log's ClearLog action. The logs are only cleared
+once confirmed, or with --yes.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			if !v.GetBool("yes") {
+				logs := "every log"
+				if patterns := v.GetStringSlice("log"); len(patterns) > 0 {
+					logs = fmt.Sprintf(
+						"the logs matching %s",
+						strings.Join(
+							patterns,
+							", ",
+						),
+					)
+				}
+				ok, err := cli.Confirm(
+					fmt.Sprintf(
+						"Clear %s of %d host(s)?",
+						logs,
+						len(hosts),
+					),
+				)
+				if err != nil {
+					cmd.CheckError(
+						fmt.Errorf(
+							"%v, use --yes to clear without confirmation",
+							err,
+						),
+					)
+				}
+				if !ok {
+					cmd.CheckError(fmt.Errorf("not confirmed, no logs cleared"))
+				}
+			}
+
+			content := set.AsyncCall(
+				clearLogs,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	c.PersistentFlags().Bool(
+		"yes",
+		false,
+		"Clear the logs without confirmation",
+	)
+	set.AddFlags(c)
+	return c
+}
+
+func clearLogs(host string) interface{} {
+	cl := Clear{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		cl.Error = err
+		return cl
+	}
+	defer c.Logout()
+
+	services, err := getLogServices(c)
+	if err != nil {
+		cl.Error = err
+		return cl
+	}
+	patterns := viper.GetStringSlice("log")
+	services, err = selectLogServices(
+		services,
+		patterns,
+	)
+	if err != nil {
+		cl.Error = err
+		return cl
+	}
+
+	var cleared []string
+	for _, s := range services {
+		if !s.clearable {
+			if len(patterns) > 0 {
+				cl.Error = fmt.Errorf(
+					"%s can not be cleared",
+					s.ID,
+				)
+				break
+			}
+			continue
+		}
+		err = s.ClearLog()
+		if err != nil {
+			cl.Error = fmt.Errorf(
+				"%s: %v",
+				s.ID,
+				err,
+			)
+			break
+		}
+		cleared = append(
+			cleared,
+			s.ID,
+		)
+	}
+	cl.Logs = strings.Join(
+		cleared,
+		", ",
+	)
+	if cl.Error == nil {
+		if len(cleared) == 0 {
+			cl.Error = fmt.Errorf("no log can be cleared")
+		} else {
+			cl.State = Cleared
+		}
+	}
+	return cl
+}
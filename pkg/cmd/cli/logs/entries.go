@@ -0,0 +1,247 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/stmcginnis/gofish/common"
+
+	"github.com/Cray-HPE/gru/internal/query"
+)
+
+// severities are the severities of a log entry, from least to most severe.
+var severities = []string{
+	"OK",
+	"Warning",
+	"Critical",
+}
+
+// Entry represents a single log entry. Message and Severity are decoded from the MessageId through the BMC's message
+// registries when the BMC does not give them, along with the Resolution of the message.
+type Entry struct {
+	Time       string `json:"time" yaml:"time"`
+	Log        string `json:"log" yaml:"log"`
+	ID         string `json:"id" yaml:"id"`
+	Severity   string `json:"severity" yaml:"severity"`
+	MessageID  string `json:"messageID,omitempty" yaml:"message_id,omitempty"`
+	Message    string `json:"message" yaml:"message"`
+	Resolution string `json:"resolution,omitempty" yaml:"resolution,omitempty"`
+}
+
+// filter is the filter every log entry must pass.
+type filter struct {
+	since    time.Time
+	severity string
+	message  string
+}
+
+type rawEntry struct {
+	ODataID     string `json:"@odata.id"`
+	ID          string `json:"Id"`
+	Created     string
+	Severity    string
+	Message     string
+	MessageID   string `json:"MessageId"`
+	MessageArgs []string
+}
+
+// parseSince parses --since, a duration before now (e.g. 1h) or a time (e.g. 2024-01-02 or 2024-01-02T15:04:05Z).
+func parseSince(since string) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(since); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	for _, layout := range []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02",
+	} {
+		if t, err := time.ParseInLocation(layout, since, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf(
+		"invalid --since %q, expected a duration (e.g. 1h) or a time (e.g. 2006-01-02T15:04:05Z)",
+		since,
+	)
+}
+
+// parseSeverity returns the canonical name of a severity given to --severity.
+func parseSeverity(severity string) (string, error) {
+	if severity == "" {
+		return "", nil
+	}
+	for _, s := range severities {
+		if strings.EqualFold(s, severity) {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf(
+		"invalid --severity %q, must be one of: %s",
+		severity,
+		strings.Join(
+			severities,
+			", ",
+		),
+	)
+}
+
+// severityRank returns the rank of a severity in severities, unknown severities rank as OK.
+func severityRank(severity string) int {
+	for i, s := range severities {
+		if strings.EqualFold(s, severity) {
+			return i
+		}
+	}
+	return 0
+}
+
+// created returns the time an entry was created, or the zero time if the BMC gave no valid time.
+func (e Entry) created() time.Time {
+	return createdTime(e.Time)
+}
+
+// createdTime parses the time an entry was created, or returns the zero time if it is not valid.
+func createdTime(created string) time.Time {
+	t, err := time.Parse(
+		time.RFC3339,
+		created,
+	)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// matches returns whether an entry passes the filter. Entries without a valid time never pass a filter with --since.
+func (f filter) matches(e Entry) bool {
+	if created := e.created(); !f.since.IsZero() && (created.IsZero() || created.Before(f.since)) {
+		return false
+	}
+	if f.severity != "" && severityRank(e.Severity) < severityRank(f.severity) {
+		return false
+	}
+	if f.message != "" {
+		text := strings.ToLower(f.message)
+		if !strings.Contains(strings.ToLower(e.Message), text) && !strings.Contains(strings.ToLower(e.MessageID), text) {
+			return false
+		}
+	}
+	return true
+}
+
+// getEntries returns the entries of a log service that pass the filter, following @odata.nextLink across pages. The
+// pages are read directly since most BMCs expand their entries in the collection, whereas gofish reads every entry on
+// its own.
+func getEntries(c common.Client, service logService, reg *registries, f filter) ([]Entry, error) {
+	var entries []Entry
+	seen := make(map[string]bool)
+	for uri := service.entries; uri != "" && !seen[uri]; {
+		seen[uri] = true
+		var page struct {
+			Members  []rawEntry
+			NextLink string `json:"Members@odata.nextLink"`
+		}
+		err := query.GetJSON(
+			c,
+			uri,
+			&page,
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page.Members {
+			if m.ID == "" && m.ODataID != "" {
+				err = query.GetJSON(
+					c,
+					m.ODataID,
+					&m,
+				)
+				if err != nil {
+					return nil, err
+				}
+			}
+			e := newEntry(
+				m,
+				service.ID,
+				reg,
+			)
+			if f.matches(e) {
+				entries = append(
+					entries,
+					e,
+				)
+			}
+		}
+		uri = page.NextLink
+	}
+	return entries, nil
+}
+
+// newEntry creates an Entry from a raw log entry, decoding its MessageId only if the BMC gave no message or severity.
+func newEntry(m rawEntry, log string, reg *registries) Entry {
+	e := Entry{
+		Time:      m.Created,
+		Log:       log,
+		ID:        m.ID,
+		Severity:  m.Severity,
+		MessageID: m.MessageID,
+		Message:   m.Message,
+	}
+	if e.Message != "" && e.Severity != "" {
+		return e
+	}
+	if msg, ok := reg.lookup(m.MessageID); ok {
+		if e.Message == "" {
+			e.Message = substitute(
+				msg.Message,
+				m.MessageArgs,
+			)
+		}
+		if e.Severity == "" {
+			e.Severity = severity(msg)
+		}
+		e.Resolution = msg.Resolution
+	}
+	return e
+}
+
+// sortEntries sorts entries chronologically, entries without a valid time first.
+func sortEntries(entries []Entry) {
+	sort.SliceStable(
+		entries,
+		func(i, j int) bool {
+			return entries[i].created().Before(entries[j].created())
+		},
+	)
+}
@@ -0,0 +1,139 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package logs
+
+import (
+	"encoding/json"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// LogService represents a single log of a host. Entries is the number of entries the log currently holds.
+type LogService struct {
+	ID              string `json:"id" yaml:"id"`
+	Source          string `json:"source" yaml:"source"`
+	Name            string `json:"name" yaml:"name"`
+	Entries         int    `json:"entries" yaml:"entries"`
+	MaxRecords      int    `json:"maxRecords,omitempty" yaml:"max_records,omitempty"`
+	OverWritePolicy string `json:"overWritePolicy,omitempty" yaml:"over_write_policy,omitempty"`
+	Clearable       bool   `json:"clearable" yaml:"clearable"`
+	URI             string `json:"uri" yaml:"uri"`
+}
+
+// LogServices represents the logs of a host.
+type LogServices struct {
+	Services []LogService `json:"services" yaml:"services"`
+	Error    error        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewListCommand creates the `list` subcommand for `logs`.
+func NewListCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "list host [...host]",
+		Short: "List logs",
+		Long:  `List the logs of the System and the Manager, with the number of entries in each.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := query.Async(
+				listLogs,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	return c
+}
+
+func listLogs(host string) interface{} {
+	ls := LogServices{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		ls.Error = err
+		return ls
+	}
+	defer c.Logout()
+
+	services, err := getLogServices(c)
+	if err != nil {
+		ls.Error = err
+		return ls
+	}
+	services, err = selectLogServices(
+		services,
+		viper.GetStringSlice("log"),
+	)
+	if err != nil {
+		ls.Error = err
+		return ls
+	}
+	for _, s := range services {
+		service := LogService{
+			ID:              s.ID,
+			Source:          s.source,
+			Name:            s.Name,
+			MaxRecords:      int(s.MaxNumberOfRecords),
+			OverWritePolicy: string(s.OverWritePolicy),
+			Clearable:       s.clearable,
+			URI:             s.ODataID,
+		}
+		if s.entries != "" {
+			var entries struct {
+				Count   *int `json:"Members@odata.count"`
+				Members []json.RawMessage
+			}
+			err = query.GetJSON(
+				c,
+				s.entries,
+				&entries,
+			)
+			if err != nil {
+				ls.Error = err
+				return ls
+			}
+			service.Entries = len(entries.Members)
+			if entries.Count != nil {
+				service.Entries = *entries.Count
+			}
+		}
+		ls.Services = append(
+			ls.Services,
+			service,
+		)
+	}
+	return ls
+}
@@ -0,0 +1,145 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package logs
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/glob"
+	"github.com/Cray-HPE/gru/internal/query"
+)
+
+// logService is a LogService with the source of its owner, and the links of it that gofish v0.20 does not expose;
+// its Entries collection and whether it has a ClearLog action.
+type logService struct {
+	*redfish.LogService
+	source    string
+	entries   string
+	clearable bool
+}
+
+// NewCommand creates the `logs` subcommand.
+func NewCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "logs",
+		Short: "BMC logs",
+		Long: `List, show, or clear the logs (e.g. the SEL, IML, or event log) of the LogServices of the System and the
+Manager.`,
+	}
+	c.AddCommand(
+		NewListCommand(),
+		NewShowCommand(),
+		NewClearCommand(),
+	)
+	c.PersistentFlags().StringSlice(
+		"log",
+		[]string{},
+		"Logs to use, patterns matching their ID or name (e.g. SEL,\"*Event*\"), instead of every log",
+	)
+	return c
+}
+
+// getLogServices returns the log services of the first system and of every manager.
+func getLogServices(c *gofish.APIClient) ([]logService, error) {
+	owned, err := query.GetOwned(
+		c,
+		(*redfish.ComputerSystem).LogServices,
+		(*redfish.Manager).LogServices,
+		func(s *redfish.LogService) string {
+			return s.ID
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []logService
+	for _, o := range owned {
+		var raw struct {
+			Entries common.Link
+			Actions struct {
+				ClearLog common.ActionTarget `json:"#LogService.ClearLog"`
+			}
+		}
+		err = query.GetJSON(
+			c,
+			o.Item.ODataID,
+			&raw,
+		)
+		if err != nil {
+			return nil, err
+		}
+		services = append(
+			services,
+			logService{
+				LogService: o.Item,
+				source:     o.Source,
+				entries:    raw.Entries.String(),
+				clearable:  raw.Actions.ClearLog.Target != "",
+			},
+		)
+	}
+	return services, nil
+}
+
+// selectLogServices returns the log services matching any of the patterns, or every log service without patterns.
+// Every pattern must match a log service.
+func selectLogServices(services []logService, patterns []string) ([]logService, error) {
+	if len(patterns) == 0 {
+		return services, nil
+	}
+	var selected []logService
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		found := false
+		for _, s := range services {
+			if glob.Match(pattern, s.ID) || glob.Match(pattern, s.Name) {
+				found = true
+				if seen[s.ODataID] {
+					continue
+				}
+				seen[s.ODataID] = true
+				selected = append(
+					selected,
+					s,
+				)
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf(
+				"no log matching %q",
+				pattern,
+			)
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,150 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/natural"
+)
+
+// language is the language of the message registries used to decode messages.
+const language = "en"
+
+// severity returns the severity of a registry message, MessageSeverity replaced the deprecated Severity.
+func severity(m redfish.MessageRegistryMessage) string {
+	if m.MessageSeverity != "" {
+		return m.MessageSeverity
+	}
+	return m.Severity
+}
+
+// registries are the message registries of a BMC, keyed by prefix and major and minor version (e.g. Base.1.8). They
+// are only read once a MessageId is decoded.
+type registries struct {
+	client   *gofish.APIClient
+	loaded   bool
+	messages map[string]map[string]redfish.MessageRegistryMessage
+}
+
+func newRegistries(c *gofish.APIClient) *registries {
+	return &registries{
+		client: c,
+	}
+}
+
+// load reads the message registries hosted by the BMC. Decoding is best-effort, registries that can not be read are
+// skipped.
+func (r *registries) load() {
+	r.loaded = true
+	r.messages = make(map[string]map[string]redfish.MessageRegistryMessage)
+	// A partially read collection still decodes the messages of the registries that were read.
+	files, _ := r.client.Service.Registries()
+	sort.Slice(
+		files,
+		func(i, j int) bool {
+			return natural.Less(
+				files[i].ID,
+				files[j].ID,
+			)
+		},
+	)
+	for _, f := range files {
+		uri := ""
+		for _, l := range f.Location {
+			if l.URI != "" && (uri == "" || strings.EqualFold(l.Language, language)) {
+				uri = l.URI
+			}
+		}
+		if uri == "" {
+			continue
+		}
+		registry, err := redfish.GetMessageRegistry(
+			r.client,
+			uri,
+		)
+		if err != nil || registry.RegistryPrefix == "" {
+			continue
+		}
+		version := strings.SplitN(
+			registry.RegistryVersion,
+			".",
+			3,
+		)
+		if len(version) < 2 {
+			continue
+		}
+		key := fmt.Sprintf(
+			"%s.%s.%s",
+			registry.RegistryPrefix,
+			version[0],
+			version[1],
+		)
+		if _, ok := r.messages[key]; !ok {
+			r.messages[key] = registry.Messages
+		}
+	}
+}
+
+// lookup returns the registry message of a MessageId (e.g. Base.1.8.PropertyValueNotInList).
+func (r *registries) lookup(messageID string) (redfish.MessageRegistryMessage, bool) {
+	if r == nil {
+		return redfish.MessageRegistryMessage{}, false
+	}
+	parts := strings.Split(
+		messageID,
+		".",
+	)
+	if len(parts) != 4 {
+		return redfish.MessageRegistryMessage{}, false
+	}
+	if !r.loaded {
+		r.load()
+	}
+	msg, ok := r.messages[strings.Join(parts[:3], ".")][parts[3]]
+	return msg, ok
+}
+
+// substitute replaces the %1, %2, ... placeholders of a registry message with the message's arguments.
+func substitute(message string, args []string) string {
+	for i := len(args); i > 0; i-- {
+		message = strings.ReplaceAll(
+			message,
+			fmt.Sprintf(
+				"%%%d",
+				i,
+			),
+			args[i-1],
+		)
+	}
+	return message
+}
@@ -0,0 +1,257 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Cray-HPE/gru/internal/natural"
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// Logs represents the log entries of a host, in chronological order.
+type Logs struct {
+	Entries []Entry `json:"entries" yaml:"entries"`
+	Error   error   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// MergedLogs represents the log entries of every host, in chronological order.
+type MergedLogs struct {
+	Entries []HostEntry `json:"entries" yaml:"entries"`
+}
+
+// HostEntry is a log entry of a host, for entries merged across hosts.
+type HostEntry struct {
+	Host       string `json:"host" yaml:"host"`
+	Time       string `json:"time" yaml:"time"`
+	Log        string `json:"log" yaml:"log"`
+	ID         string `json:"id" yaml:"id"`
+	Severity   string `json:"severity" yaml:"severity"`
+	MessageID  string `json:"messageID,omitempty" yaml:"message_id,omitempty"`
+	Message    string `json:"message" yaml:"message"`
+	Resolution string `json:"resolution,omitempty" yaml:"resolution,omitempty"`
+}
+
+// Merged is the key of the entries merged across hosts.
+const Merged = "merged"
+
+// NewShowCommand creates the `show` subcommand for `logs`.
+func NewShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "show host [...host]",
+		Short: "Show log entries",
+		Long: `Show the entries of every log (or of each --log) in chronological order, following the BMC's paging.
+Messages and severities the BMC does not give are decoded from their MessageId through the BMC's message registries,
+which are only read for such entries.
+
+Entries are shown per host, or with --merge, merged chronologically across every host under "merged"; hosts that
+failed are still shown on their own.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			since, err := parseSince(v.GetString("since"))
+			cmd.CheckError(err)
+			severity, err := parseSeverity(v.GetString("severity"))
+			cmd.CheckError(err)
+			f := filter{
+				since:    since,
+				severity: severity,
+				message:  v.GetString("message"),
+			}
+
+			content := query.Async(
+				func(host string) interface{} {
+					return showLogs(
+						host,
+						f,
+					)
+				},
+				hosts,
+			)
+			if v.GetBool("merge") {
+				content = merge(content)
+			}
+			cli.PrettyPrint(content)
+		},
+	}
+	c.PersistentFlags().String(
+		"since",
+		"",
+		"Only show entries created since a duration ago (e.g. 1h) or a time (e.g. 2006-01-02T15:04:05Z)",
+	)
+	c.PersistentFlags().String(
+		"severity",
+		"",
+		fmt.Sprintf(
+			"Only show entries of at least this severity; %s",
+			strings.Join(
+				severities,
+				", ",
+			),
+		),
+	)
+	c.PersistentFlags().String(
+		"message",
+		"",
+		"Only show entries whose message or MessageId contains this text (case-insensitive)",
+	)
+	c.PersistentFlags().Bool(
+		"merge",
+		false,
+		"Merge the entries of every host chronologically",
+	)
+	return c
+}
+
+func showLogs(host string, f filter) interface{} {
+	logs := Logs{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		logs.Error = err
+		return logs
+	}
+	defer c.Logout()
+
+	services, err := getLogServices(c)
+	if err != nil {
+		logs.Error = err
+		return logs
+	}
+	services, err = selectLogServices(
+		services,
+		viper.GetStringSlice("log"),
+	)
+	if err != nil {
+		logs.Error = err
+		return logs
+	}
+	reg := newRegistries(c)
+	for _, s := range services {
+		if s.entries == "" {
+			continue
+		}
+		entries, err := getEntries(
+			c,
+			s,
+			reg,
+			f,
+		)
+		if err != nil {
+			logs.Error = fmt.Errorf(
+				"%s: %v",
+				s.ID,
+				err,
+			)
+			return logs
+		}
+		logs.Entries = append(
+			logs.Entries,
+			entries...,
+		)
+	}
+	sortEntries(logs.Entries)
+	return logs
+}
+
+// merge replaces the logs of every host that did not fail with their entries merged chronologically.
+func merge(content map[string]interface{}) map[string]interface{} {
+	hosts := make(
+		[]string,
+		0,
+		len(content),
+	)
+	for host := range content {
+		hosts = append(
+			hosts,
+			host,
+		)
+	}
+	sort.Slice(
+		hosts,
+		func(i, j int) bool {
+			return natural.Less(
+				hosts[i],
+				hosts[j],
+			)
+		},
+	)
+
+	merged := MergedLogs{
+		Entries: []HostEntry{},
+	}
+	result := make(map[string]interface{})
+	for _, host := range hosts {
+		logs, ok := content[host].(Logs)
+		if !ok || logs.Error != nil {
+			result[host] = content[host]
+			continue
+		}
+		for _, e := range logs.Entries {
+			merged.Entries = append(
+				merged.Entries,
+				newHostEntry(
+					host,
+					e,
+				),
+			)
+		}
+	}
+	sort.SliceStable(
+		merged.Entries,
+		func(i, j int) bool {
+			return createdTime(merged.Entries[i].Time).Before(createdTime(merged.Entries[j].Time))
+		},
+	)
+	result[Merged] = merged
+	return result
+}
+
+// newHostEntry creates a HostEntry from a log entry of a host.
+func newHostEntry(host string, e Entry) HostEntry {
+	return HostEntry{
+		Host:       host,
+		Time:       e.Time,
+		Log:        e.Log,
+		ID:         e.ID,
+		Severity:   e.Severity,
+		MessageID:  e.MessageID,
+		Message:    e.Message,
+		Resolution: e.Resolution,
+	}
+}
@@ -0,0 +1,54 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package memory
+
+// Memory represents a host's memory; a summary of its DIMMs, and the DIMMs in every slot. Uniform is true when every
+// populated DIMM has the same capacity, speed, type, manufacturer, and part number, otherwise Differences lists each
+// property that differs with the count of DIMMs for each of its values.
+type Memory struct {
+	TotalCapacityMiB int      `json:"totalCapacityMiB" yaml:"total_capacity_mib"`
+	PopulatedSlots   int      `json:"populatedSlots" yaml:"populated_slots"`
+	TotalSlots       int      `json:"totalSlots" yaml:"total_slots"`
+	Uniform          bool     `json:"uniform" yaml:"uniform"`
+	Differences      []string `json:"differences,omitempty" yaml:"differences,omitempty"`
+	Unhealthy        []string `json:"unhealthy,omitempty" yaml:"unhealthy,omitempty"`
+	DIMMs            []DIMM   `json:"dimms" yaml:"dimms"`
+	Error            error    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// DIMM represents a single memory slot and the DIMM in it, if any.
+type DIMM struct {
+	Slot         string `json:"slot" yaml:"slot"`
+	CapacityMiB  int    `json:"capacityMiB" yaml:"capacity_mib"`
+	SpeedMHz     int    `json:"speedMHz" yaml:"speed_mhz"`
+	Type         string `json:"type" yaml:"type"`
+	Manufacturer string `json:"manufacturer" yaml:"manufacturer"`
+	PartNumber   string `json:"partNumber" yaml:"part_number"`
+	SerialNumber string `json:"serialNumber" yaml:"serial_number"`
+	Health       string `json:"health" yaml:"health"`
+	State        string `json:"state" yaml:"state"`
+}
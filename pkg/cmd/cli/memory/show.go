@@ -0,0 +1,198 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/natural"
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewShowCommand creates the `memory` subcommand for `show`.
+func NewShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "memory host [...host]",
+		Short: "Memory (DIMM) information",
+		Long: `Show the Server's memory; the total capacity, populated slots, whether the populated DIMMs are uniform,
+any unhealthy DIMMs, and every slot with its DIMM's capacity, speed, type, manufacturer, part, and serial number.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+			content := query.Async(
+				getMemory,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	return c
+}
+
+func getMemory(host string) interface{} {
+	m := Memory{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		m.Error = err
+		return m
+	}
+	defer c.Logout()
+
+	systems, err := c.Service.Systems()
+	if err != nil || len(systems) < 1 {
+		m.Error = err
+		return m
+	}
+	memory, err := systems[0].Memory()
+	if err != nil {
+		m.Error = err
+		return m
+	}
+
+	for _, dimm := range memory {
+		m.DIMMs = append(
+			m.DIMMs,
+			DIMM{
+				Slot:         slot(dimm),
+				CapacityMiB:  dimm.CapacityMiB,
+				SpeedMHz:     dimm.OperatingSpeedMhz,
+				Type:         string(dimm.MemoryDeviceType),
+				Manufacturer: strings.TrimSpace(dimm.Manufacturer),
+				PartNumber:   strings.TrimSpace(dimm.PartNumber),
+				SerialNumber: strings.TrimSpace(dimm.SerialNumber),
+				Health:       string(dimm.Status.Health),
+				State:        string(dimm.Status.State),
+			},
+		)
+	}
+	sort.SliceStable(m.DIMMs, func(i, j int) bool {
+		return natural.Less(m.DIMMs[i].Slot, m.DIMMs[j].Slot)
+	})
+	summarize(&m)
+	return m
+}
+
+// slot returns the locator of a memory slot, falling back to its location and then its name.
+func slot(dimm *redfish.Memory) string {
+	if dimm.DeviceLocator != "" {
+		return dimm.DeviceLocator
+	}
+	if label := dimm.Location.PartLocation.ServiceLabel; label != "" {
+		return label
+	}
+	if dimm.Name != "" {
+		return dimm.Name
+	}
+	return dimm.ID
+}
+
+// present returns whether a slot has a DIMM in it, a failed or disabled DIMM may report no capacity.
+func present(dimm DIMM) bool {
+	return dimm.State != string(common.AbsentState)
+}
+
+// populated returns whether a slot has a usable DIMM in it, one that reports its capacity.
+func populated(dimm DIMM) bool {
+	return present(dimm) && dimm.CapacityMiB > 0
+}
+
+// summarize sets the summary of a host's memory from its DIMMs.
+func summarize(m *Memory) {
+	properties := []struct {
+		name  string
+		value func(DIMM) string
+	}{
+		{"capacity", func(d DIMM) string { return fmt.Sprintf("%d MiB", d.CapacityMiB) }},
+		{"speed", func(d DIMM) string { return fmt.Sprintf("%d MHz", d.SpeedMHz) }},
+		{"type", func(d DIMM) string { return d.Type }},
+		{"manufacturer", func(d DIMM) string { return d.Manufacturer }},
+		{"part number", func(d DIMM) string { return d.PartNumber }},
+	}
+	counts := make([]map[string]int, len(properties))
+	for i := range counts {
+		counts[i] = make(map[string]int)
+	}
+
+	m.TotalSlots = len(m.DIMMs)
+	for _, dimm := range m.DIMMs {
+		if present(dimm) && dimm.Health != "" && dimm.Health != string(common.OKHealth) {
+			m.Unhealthy = append(
+				m.Unhealthy,
+				fmt.Sprintf(
+					"%s: %s",
+					dimm.Slot,
+					dimm.Health,
+				),
+			)
+		}
+		if !populated(dimm) {
+			continue
+		}
+		m.PopulatedSlots++
+		m.TotalCapacityMiB += dimm.CapacityMiB
+		for i, property := range properties {
+			counts[i][property.value(dimm)]++
+		}
+	}
+
+	for i, property := range properties {
+		if len(counts[i]) < 2 {
+			continue
+		}
+		var values []string
+		for value, count := range counts[i] {
+			values = append(
+				values,
+				fmt.Sprintf(
+					"%s x%d",
+					value,
+					count,
+				),
+			)
+		}
+		sort.Strings(values)
+		m.Differences = append(
+			m.Differences,
+			fmt.Sprintf(
+				"%s: %s",
+				property.name,
+				strings.Join(
+					values,
+					", ",
+				),
+			),
+		)
+	}
+	m.Uniform = len(m.Differences) == 0
+}
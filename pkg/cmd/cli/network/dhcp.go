@@ -0,0 +1,514 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Cray-HPE/gru/internal/natural"
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/chassis/boot"
+)
+
+// Formats of a DHCP reservation file.
+const (
+	DnsmasqFormat = "dnsmasq"
+	ISCFormat     = "isc-dhcpd"
+	KeaFormat     = "kea-json"
+)
+
+var formats = []string{
+	DnsmasqFormat,
+	ISCFormat,
+	KeaFormat,
+}
+
+// Mapping is the hostname and IP address a host's reservation is for.
+type Mapping struct {
+	Hostname string `yaml:"hostname"`
+	IP       string `yaml:"ip"`
+}
+
+// Reservation represents a host's DHCP reservation; the MAC address of its chosen interface and its mapping.
+type Reservation struct {
+	Host      string `json:"host" yaml:"host"`
+	Interface string `json:"interface" yaml:"interface"`
+	MAC       string `json:"mac" yaml:"mac"`
+	Hostname  string `json:"hostname" yaml:"hostname"`
+	IP        string `json:"ip" yaml:"ip"`
+	Error     error  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewExportDHCPCommand creates the `dhcp` subcommand for `export`.
+func NewExportDHCPCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "dhcp host [...host]",
+		Short: "DHCP reservations",
+		Long: fmt.Sprintf(
+			`Write a DHCP reservation for each host from the MAC address of one of its interfaces and a mapping file.
+
+The mapping file is YAML, keyed by host:
+
+  node1-bmc:
+    hostname: node1
+    ip: 10.0.0.5
+
+The interface is the system's first Ethernet interface with a MAC address unless one is chosen by:
+  --interface    an Ethernet interface ID or name, or its index (from 0)
+  --boot-option  a boot option selector as in "boot order set", its UEFI device path gives the MAC address
+
+With --output the file is only written, in one step, once every host has a reservation. isc-dhcpd reservations are
+IPv4 only.
+
+Formats: %s`,
+			strings.Join(
+				formats,
+				", ",
+			),
+		),
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			format := v.GetString("format")
+			if !validFormat(format) {
+				cmd.CheckError(
+					fmt.Errorf(
+						"invalid format %q, must be one of: %s",
+						format,
+						strings.Join(
+							formats,
+							", ",
+						),
+					),
+				)
+			}
+			mapping := v.GetString("mapping")
+			if mapping == "" {
+				cmd.CheckError(fmt.Errorf("no mapping file given"))
+			}
+			mappings, err := readMappings(mapping)
+			cmd.CheckError(err)
+
+			content := query.Async(
+				getReservation,
+				hosts,
+			)
+
+			var reservations []Reservation
+			var failed []string
+			for host, result := range content {
+				r := result.(Reservation)
+				if r.Error == nil {
+					r.Error = mapReservation(
+						&r,
+						mappings,
+						format,
+					)
+				}
+				if r.Error != nil {
+					failed = append(
+						failed,
+						fmt.Sprintf(
+							"%s: %v",
+							host,
+							r.Error,
+						),
+					)
+					continue
+				}
+				reservations = append(
+					reservations,
+					r,
+				)
+			}
+			sort.Slice(reservations, func(i, j int) bool {
+				return natural.Less(
+					reservations[i].Hostname,
+					reservations[j].Hostname,
+				)
+			})
+			sort.Strings(failed)
+
+			out, err := render(
+				format,
+				reservations,
+			)
+			cmd.CheckError(err)
+			output := v.GetString("output")
+			toStdout := output == "" || output == "-"
+			if toStdout {
+				fmt.Print(out)
+			} else if len(failed) == 0 {
+				err = writeFile(
+					output,
+					out,
+				)
+				cmd.CheckError(err)
+			}
+
+			if len(failed) > 0 {
+				for _, f := range failed {
+					fmt.Fprintln(
+						os.Stderr,
+						f,
+					)
+				}
+				err = fmt.Errorf(
+					"%d host(s) have no reservation",
+					len(failed),
+				)
+				if !toStdout {
+					err = fmt.Errorf(
+						"%w, %s was not written",
+						err,
+						output,
+					)
+				}
+				cmd.CheckError(err)
+			}
+		},
+	}
+	c.PersistentFlags().StringP(
+		"format",
+		"f",
+		DnsmasqFormat,
+		fmt.Sprintf(
+			"Reservation file format; %s",
+			strings.Join(
+				formats,
+				", ",
+			),
+		),
+	)
+	c.PersistentFlags().StringP(
+		"mapping",
+		"m",
+		"",
+		"YAML file mapping each host to a hostname and IP address",
+	)
+	c.PersistentFlags().StringP(
+		"output",
+		"o",
+		"-",
+		"File to write the reservations to, - for stdout",
+	)
+	c.PersistentFlags().String(
+		"interface",
+		"",
+		"Ethernet interface ID, name, or index (from 0) to take the MAC address from",
+	)
+	c.PersistentFlags().String(
+		"boot-option",
+		"",
+		"Boot option selector to take the MAC address from (e.g. class:network, Boot0001, \"*Mellanox*\")",
+	)
+	c.MarkFlagsMutuallyExclusive(
+		"interface",
+		"boot-option",
+	)
+	return c
+}
+
+func validFormat(format string) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// readMappings reads a YAML mapping file.
+func readMappings(file string) (map[string]Mapping, error) {
+	mappings := make(map[string]Mapping)
+	if !regexp.MustCompile(`ya?ml`).MatchString(filepath.Ext(file)) {
+		return mappings, fmt.Errorf(
+			"invalid filetype: %s",
+			filepath.Ext(file),
+		)
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return mappings, err
+	}
+	err = yaml.Unmarshal(
+		b,
+		&mappings,
+	)
+	return mappings, err
+}
+
+// mapReservation sets the hostname and IP address of a reservation from its host's mapping. isc-dhcpd host
+// declarations are IPv4 only, so an IPv6 address is refused for that format.
+func mapReservation(r *Reservation, mappings map[string]Mapping, format string) error {
+	m, ok := mappings[r.Host]
+	if !ok {
+		return fmt.Errorf("no mapping")
+	}
+	if m.Hostname == "" {
+		return fmt.Errorf("no hostname in mapping")
+	}
+	if net.ParseIP(m.IP) == nil {
+		return fmt.Errorf(
+			"invalid IP address in mapping: %q",
+			m.IP,
+		)
+	}
+	if format == ISCFormat && strings.Contains(m.IP, ":") {
+		return fmt.Errorf(
+			"%s reservations are IPv4 only, the mapping has %s",
+			ISCFormat,
+			m.IP,
+		)
+	}
+	r.Hostname = m.Hostname
+	r.IP = m.IP
+	return nil
+}
+
+func getReservation(host string) interface{} {
+	r := Reservation{Host: host}
+	c, err := auth.Connection(host)
+	if err != nil {
+		r.Error = err
+		return r
+	}
+	defer c.Logout()
+
+	systems, err := c.Service.Systems()
+	if err != nil || len(systems) < 1 {
+		r.Error = err
+		return r
+	}
+
+	v := viper.GetViper()
+	if selector := v.GetString("boot-option"); selector != "" {
+		options, err := boot.Find(
+			systems[0],
+			selector,
+		)
+		if err != nil {
+			r.Error = err
+			return r
+		}
+		for _, o := range options {
+			if o.MAC() != "" {
+				r.Interface = o.Reference
+				r.MAC = o.MAC()
+				return r
+			}
+		}
+		r.Error = fmt.Errorf(
+			"no boot option matching %q has a MAC address",
+			selector,
+		)
+		return r
+	}
+
+	interfaces, err := systems[0].EthernetInterfaces()
+	if err != nil {
+		r.Error = err
+		return r
+	}
+	i, err := chooseInterface(
+		newInterfaces(interfaces),
+		v.GetString("interface"),
+	)
+	if err != nil {
+		r.Error = err
+		return r
+	}
+	r.Interface = i.ID
+	r.MAC = i.MAC
+	return r
+}
+
+// chooseInterface returns the interface matching a selector; an index, ID, or name. Without a selector the first
+// interface with a MAC address is returned.
+func chooseInterface(interfaces []Interface, selector string) (Interface, error) {
+	if selector == "" {
+		for _, i := range interfaces {
+			if i.MAC != "" {
+				return i, nil
+			}
+		}
+		return Interface{}, fmt.Errorf("no interface has a MAC address")
+	}
+
+	var chosen *Interface
+	for k, i := range interfaces {
+		if strings.EqualFold(i.ID, selector) || strings.EqualFold(i.Name, selector) {
+			chosen = &interfaces[k]
+			break
+		}
+	}
+	if index, err := strconv.Atoi(selector); chosen == nil && err == nil && index >= 0 && index < len(interfaces) {
+		chosen = &interfaces[index]
+	}
+	if chosen == nil {
+		return Interface{}, fmt.Errorf(
+			"no interface matching %q",
+			selector,
+		)
+	}
+	if chosen.MAC == "" {
+		return Interface{}, fmt.Errorf(
+			"interface %s has no MAC address",
+			chosen.ID,
+		)
+	}
+	return *chosen, nil
+}
+
+// writeFile replaces a file with the given content through a temporary file in the same directory, so the file is
+// never left partially written.
+func writeFile(file string, content string) error {
+	tmp, err := os.CreateTemp(
+		filepath.Dir(file),
+		"."+filepath.Base(file)+".*",
+	)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.WriteString(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(
+		tmp.Name(),
+		0644,
+	)
+	if err != nil {
+		return err
+	}
+	return os.Rename(
+		tmp.Name(),
+		file,
+	)
+}
+
+// render formats reservations as a reservation file.
+func render(format string, reservations []Reservation) (string, error) {
+	var b strings.Builder
+	switch format {
+	case DnsmasqFormat:
+		for _, r := range reservations {
+			ip := r.IP
+			if strings.Contains(ip, ":") {
+				ip = fmt.Sprintf(
+					"[%s]",
+					ip,
+				)
+			}
+			fmt.Fprintf(
+				&b,
+				"dhcp-host=%s,%s,%s\n",
+				r.MAC,
+				ip,
+				r.Hostname,
+			)
+		}
+	case ISCFormat:
+		for _, r := range reservations {
+			fmt.Fprintf(
+				&b,
+				"host %s {\n  hardware ethernet %s;\n  fixed-address %s;\n  option host-name \"%s\";\n}\n",
+				r.Hostname,
+				r.MAC,
+				r.IP,
+				r.Hostname,
+			)
+		}
+	case KeaFormat:
+		type keaReservation struct {
+			HWAddress   string   `json:"hw-address"`
+			IPAddress   string   `json:"ip-address,omitempty"`
+			IPAddresses []string `json:"ip-addresses,omitempty"`
+			Hostname    string   `json:"hostname"`
+		}
+		kea := struct {
+			Reservations []keaReservation `json:"reservations"`
+		}{
+			Reservations: []keaReservation{},
+		}
+		for _, r := range reservations {
+			k := keaReservation{
+				HWAddress: r.MAC,
+				Hostname:  r.Hostname,
+			}
+			if strings.Contains(r.IP, ":") {
+				k.IPAddresses = []string{r.IP}
+			} else {
+				k.IPAddress = r.IP
+			}
+			kea.Reservations = append(
+				kea.Reservations,
+				k,
+			)
+		}
+		out, err := json.MarshalIndent(
+			kea,
+			"",
+			"  ",
+		)
+		if err != nil {
+			return "", err
+		}
+		b.Write(out)
+		b.WriteString("\n")
+	default:
+		return "", fmt.Errorf(
+			"invalid format %q",
+			format,
+		)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,302 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package network
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/natural"
+	"github.com/Cray-HPE/gru/internal/query"
+)
+
+// Sources of a MAC address.
+const (
+	SystemSource  = "system"
+	AdapterSource = "adapter"
+	BMCSource     = "bmc"
+)
+
+// Network represents a host's network interfaces; the system's Ethernet interfaces, the ports of its network
+// adapters, and the BMC's own Ethernet interfaces.
+type Network struct {
+	Interfaces []Interface `json:"interfaces" yaml:"interfaces"`
+	Ports      []Port      `json:"ports,omitempty" yaml:"ports,omitempty"`
+	BMC        []Interface `json:"bmc" yaml:"bmc"`
+	Error      error       `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Interface represents a single Ethernet interface. IPv4 and IPv6 are comma delimited lists of addresses.
+type Interface struct {
+	ID         string `json:"id" yaml:"id"`
+	Name       string `json:"name" yaml:"name"`
+	MAC        string `json:"mac" yaml:"mac"`
+	LinkStatus string `json:"linkStatus" yaml:"link_status"`
+	SpeedMbps  int    `json:"speedMbps" yaml:"speed_mbps"`
+	IPv4       string `json:"ipv4" yaml:"ipv4"`
+	IPv6       string `json:"ipv6" yaml:"ipv6"`
+}
+
+// Port represents a single port of a network adapter. Location is the adapter's PCIe slot.
+type Port struct {
+	Adapter    string `json:"adapter" yaml:"adapter"`
+	ID         string `json:"id" yaml:"id"`
+	MAC        string `json:"mac" yaml:"mac"`
+	LinkStatus string `json:"linkStatus" yaml:"link_status"`
+	SpeedMbps  int    `json:"speedMbps" yaml:"speed_mbps"`
+	Location   string `json:"location" yaml:"location"`
+}
+
+// MAC represents a single MAC address of a host, see `--macs`.
+type MAC struct {
+	Source    string `json:"source" yaml:"source"`
+	Interface string `json:"interface" yaml:"interface"`
+	MAC       string `json:"mac" yaml:"mac"`
+}
+
+// newInterfaces creates an Interface from each Ethernet interface, ordered by ID.
+func newInterfaces(list []*redfish.EthernetInterface) []Interface {
+	sort.Slice(
+		list,
+		func(i, j int) bool {
+			return natural.Less(
+				list[i].ID,
+				list[j].ID,
+			)
+		},
+	)
+	var interfaces []Interface
+	for _, i := range list {
+		interfaces = append(
+			interfaces,
+			newInterface(i),
+		)
+	}
+	return interfaces
+}
+
+// newInterface creates an Interface from an Ethernet interface.
+func newInterface(i *redfish.EthernetInterface) Interface {
+	var ipv4, ipv6 []string
+	for _, a := range i.IPv4Addresses {
+		ipv4 = append(
+			ipv4,
+			a.Address,
+		)
+	}
+	for _, a := range i.IPv6Addresses {
+		ipv6 = append(
+			ipv6,
+			a.Address,
+		)
+	}
+	iface := Interface{
+		ID:         i.ID,
+		Name:       i.Name,
+		MAC:        strings.ToLower(i.MACAddress),
+		LinkStatus: string(i.LinkStatus),
+		SpeedMbps:  i.SpeedMbps,
+		IPv4:       addresses(ipv4),
+		IPv6:       addresses(ipv6),
+	}
+	if iface.MAC == "" {
+		iface.MAC = strings.ToLower(i.PermanentMACAddress)
+	}
+	return iface
+}
+
+// addresses joins the non-empty addresses in a list.
+func addresses(list []string) string {
+	var joined []string
+	for _, a := range list {
+		if a != "" {
+			joined = append(
+				joined,
+				a,
+			)
+		}
+	}
+	return strings.Join(
+		joined,
+		", ",
+	)
+}
+
+// getPorts returns the ports of the network adapters of a chassis, ordered by adapter and port ID. Adapters without
+// Ports fall back to their (deprecated) NetworkPorts.
+func getPorts(chassis *redfish.Chassis) ([]Port, error) {
+	adapters, err := chassis.NetworkAdapters()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		adapters,
+		func(i, j int) bool {
+			return natural.Less(
+				adapters[i].ID,
+				adapters[j].ID,
+			)
+		},
+	)
+
+	var ports []Port
+	for _, adapter := range adapters {
+		name := adapter.Name
+		if adapter.Model != "" {
+			name = adapter.Model
+		}
+		location := adapterLocation(adapter)
+
+		members, err := adapter.Ports()
+		if err != nil {
+			return nil, err
+		}
+		var list []Port
+		for _, member := range members {
+			port := Port{
+				Adapter:    name,
+				ID:         member.ID,
+				LinkStatus: string(member.LinkStatus),
+				SpeedMbps:  int(member.CurrentSpeedGbps * 1000),
+				Location:   location,
+			}
+			if macs := member.Ethernet.AssociatedMACAddresses; len(macs) > 0 {
+				port.MAC = strings.ToLower(macs[0])
+			}
+			list = append(
+				list,
+				port,
+			)
+		}
+		if len(members) == 0 {
+			networkPorts, err := adapter.NetworkPorts()
+			if err != nil {
+				return nil, err
+			}
+			for _, member := range networkPorts {
+				port := Port{
+					Adapter:    name,
+					ID:         member.ID,
+					LinkStatus: string(member.LinkStatus),
+					SpeedMbps:  member.CurrentLinkSpeedMbps,
+					Location:   location,
+				}
+				if macs := member.AssociatedNetworkAddresses; len(macs) > 0 {
+					port.MAC = strings.ToLower(macs[0])
+				}
+				list = append(
+					list,
+					port,
+				)
+			}
+		}
+		sort.Slice(
+			list,
+			func(i, j int) bool {
+				return natural.Less(
+					list[i].ID,
+					list[j].ID,
+				)
+			},
+		)
+		ports = append(
+			ports,
+			list...,
+		)
+	}
+	return ports, nil
+}
+
+// adapterLocation returns the PCIe slot of a network adapter, from its location, its controller's location, or the
+// slot of its controller's PCIe device.
+func adapterLocation(adapter *redfish.NetworkAdapter) string {
+	locations := []common.Location{adapter.Location}
+	for _, controller := range adapter.Controllers {
+		locations = append(
+			locations,
+			controller.Location,
+		)
+	}
+	for _, uri := range controllerDevices(adapter) {
+		device, err := redfish.GetPCIeDevice(
+			adapter.GetClient(),
+			uri,
+		)
+		if err == nil {
+			locations = append(
+				locations,
+				device.Slot.Location,
+			)
+		}
+	}
+
+	for _, l := range locations {
+		if label := l.PartLocation.ServiceLabel; label != "" {
+			return label
+		}
+		if l.PartLocation.LocationType != "" {
+			return fmt.Sprintf(
+				"%s %d",
+				l.PartLocation.LocationType,
+				l.PartLocation.LocationOrdinalValue,
+			)
+		}
+	}
+	return ""
+}
+
+// controllerDevices returns the PCIe device links of a network adapter's controllers. These are read from the raw
+// adapter since gofish v0.20 fills Controllers.PCIeDevices from the NetworkDeviceFunctions links.
+func controllerDevices(adapter *redfish.NetworkAdapter) []string {
+	var raw struct {
+		Controllers []struct {
+			Links struct {
+				PCIeDevices common.Links
+			}
+		}
+	}
+	err := query.GetJSON(
+		adapter.GetClient(),
+		adapter.ODataID,
+		&raw,
+	)
+	if err != nil {
+		return nil
+	}
+	var devices []string
+	for _, controller := range raw.Controllers {
+		devices = append(
+			devices,
+			controller.Links.PCIeDevices.ToStrings()...,
+		)
+	}
+	return devices
+}
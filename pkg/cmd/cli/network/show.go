@@ -0,0 +1,165 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package network
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewShowCommand creates the `network` subcommand for `show`.
+func NewShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "network host [...host]",
+		Short: "Network interfaces and MAC addresses",
+		Long: `Show the Server's Ethernet interfaces with their MAC address, link status, speed, and IP addresses, the
+ports of its network adapters with their PCIe slot, and the BMC's own Ethernet interfaces.
+
+--macs only shows each host's MAC addresses.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := query.Async(
+				getNetwork,
+				hosts,
+			)
+			if v.GetBool("macs") {
+				for host, result := range content {
+					content[host] = macs(result.(Network))
+				}
+			}
+			cli.PrettyPrint(content)
+		},
+	}
+	c.PersistentFlags().Bool(
+		"macs",
+		false,
+		"Only show each host's MAC addresses",
+	)
+	return c
+}
+
+func getNetwork(host string) interface{} {
+	n := Network{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		n.Error = err
+		return n
+	}
+	defer c.Logout()
+	service := c.Service
+
+	systems, err := service.Systems()
+	if err != nil || len(systems) < 1 {
+		n.Error = err
+		return n
+	}
+	interfaces, err := systems[0].EthernetInterfaces()
+	if err != nil {
+		n.Error = err
+		return n
+	}
+	n.Interfaces = newInterfaces(interfaces)
+
+	chassis, err := service.Chassis()
+	if err != nil {
+		n.Error = err
+		return n
+	}
+	for _, ch := range chassis {
+		ports, err := getPorts(ch)
+		if err != nil {
+			n.Error = err
+			return n
+		}
+		n.Ports = append(
+			n.Ports,
+			ports...,
+		)
+	}
+
+	managers, err := service.Managers()
+	if err != nil {
+		n.Error = err
+		return n
+	}
+	for _, manager := range managers {
+		interfaces, err := manager.EthernetInterfaces()
+		if err != nil {
+			n.Error = err
+			return n
+		}
+		n.BMC = append(
+			n.BMC,
+			newInterfaces(interfaces)...,
+		)
+	}
+	return n
+}
+
+// macs returns the distinct MAC addresses of a host, in the order of its system interfaces, adapter ports, and BMC
+// interfaces. A host that failed is returned as is.
+func macs(n Network) interface{} {
+	if n.Error != nil {
+		return n
+	}
+	seen := make(map[string]bool)
+	list := []MAC{}
+	add := func(source string, iface string, mac string) {
+		if mac == "" || seen[mac] {
+			return
+		}
+		seen[mac] = true
+		list = append(
+			list,
+			MAC{
+				Source:    source,
+				Interface: iface,
+				MAC:       mac,
+			},
+		)
+	}
+	for _, i := range n.Interfaces {
+		add(SystemSource, i.ID, i.MAC)
+	}
+	for _, p := range n.Ports {
+		add(AdapterSource, p.Adapter+" "+p.ID, p.MAC)
+	}
+	for _, i := range n.BMC {
+		add(BMCSource, i.ID, i.MAC)
+	}
+	return list
+}
@@ -0,0 +1,255 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package pcie
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/stmcginnis/gofish/common"
+
+	"github.com/Cray-HPE/gru/internal/query"
+)
+
+// PCIe represents a host's PCIe devices. Degraded lists each device whose link trained at fewer lanes than it supports.
+type PCIe struct {
+	Devices  []Device `json:"devices" yaml:"devices"`
+	Degraded []string `json:"degraded,omitempty" yaml:"degraded,omitempty"`
+	Error    error    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Device represents a single PCIe device and its functions.
+type Device struct {
+	ID           string     `json:"id" yaml:"id"`
+	Name         string     `json:"name" yaml:"name"`
+	Manufacturer string     `json:"manufacturer" yaml:"manufacturer"`
+	Model        string     `json:"model" yaml:"model"`
+	Slot         string     `json:"slot" yaml:"slot"`
+	Firmware     string     `json:"firmware" yaml:"firmware"`
+	LanesInUse   int        `json:"lanesInUse" yaml:"lanes_in_use"`
+	MaxLanes     int        `json:"maxLanes" yaml:"max_lanes"`
+	PCIeType     string     `json:"pcieType" yaml:"pcie_type"`
+	MaxPCIeType  string     `json:"maxPCIeType" yaml:"max_pcie_type"`
+	Degraded     bool       `json:"degraded" yaml:"degraded"`
+	Health       string     `json:"health" yaml:"health"`
+	Functions    []Function `json:"functions,omitempty" yaml:"functions,omitempty"`
+}
+
+// Function represents a single PCIe function of a device.
+type Function struct {
+	ID                string `json:"id" yaml:"id"`
+	DeviceClass       string `json:"deviceClass" yaml:"device_class"`
+	ClassCode         string `json:"classCode" yaml:"class_code"`
+	VendorID          string `json:"vendorID" yaml:"vendor_id"`
+	DeviceID          string `json:"deviceID" yaml:"device_id"`
+	SubsystemVendorID string `json:"subsystemVendorID" yaml:"subsystem_vendor_id"`
+	SubsystemID       string `json:"subsystemID" yaml:"subsystem_id"`
+}
+
+type rawDevice struct {
+	ODataID         string `json:"@odata.id"`
+	ID              string `json:"Id"`
+	Name            string
+	Manufacturer    string
+	Model           string
+	FirmwareVersion string
+	Status          common.Status
+	Slot            struct {
+		Location struct {
+			PartLocation struct {
+				ServiceLabel         string
+				LocationType         string
+				LocationOrdinalValue *int
+			}
+		}
+	}
+	PCIeInterface struct {
+		PCIeType    string
+		MaxPCIeType string
+		LanesInUse  *int
+		MaxLanes    *int
+	}
+	PCIeFunctions common.Link
+	Links         struct {
+		PCIeFunctions common.Links
+	}
+}
+
+type rawFunction struct {
+	ID                string `json:"Id"`
+	DeviceClass       string
+	ClassCode         string
+	VendorID          string `json:"VendorId"`
+	DeviceID          string `json:"DeviceId"`
+	SubsystemVendorID string `json:"SubsystemVendorId"`
+	SubsystemID       string `json:"SubsystemId"`
+}
+
+// deviceLinks returns the PCIe devices linked from a system or chassis, either as an array of links or as a link to
+// a collection.
+func deviceLinks(client common.Client, uri string) ([]string, error) {
+	var raw struct {
+		PCIeDevices json.RawMessage
+	}
+	err := query.GetJSON(
+		client,
+		uri,
+		&raw,
+	)
+	if err != nil || len(raw.PCIeDevices) == 0 {
+		return nil, err
+	}
+
+	var links common.Links
+	if json.Unmarshal(raw.PCIeDevices, &links) == nil {
+		return links.ToStrings(), nil
+	}
+	var link common.Link
+	err = json.Unmarshal(
+		raw.PCIeDevices,
+		&link,
+	)
+	if err != nil || link == "" {
+		return nil, err
+	}
+	collection, err := common.GetCollection(
+		client,
+		link.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return collection.ItemLinks, nil
+}
+
+// getDevice returns a PCIe device and its functions.
+func getDevice(client common.Client, uri string) (Device, error) {
+	var raw rawDevice
+	err := query.GetJSON(
+		client,
+		uri,
+		&raw,
+	)
+	if err != nil {
+		return Device{}, err
+	}
+	d := Device{
+		ID:           raw.ID,
+		Name:         strings.TrimSpace(raw.Name),
+		Manufacturer: strings.TrimSpace(raw.Manufacturer),
+		Model:        strings.TrimSpace(raw.Model),
+		Slot:         slot(raw),
+		Firmware:     strings.TrimSpace(raw.FirmwareVersion),
+		PCIeType:     raw.PCIeInterface.PCIeType,
+		MaxPCIeType:  raw.PCIeInterface.MaxPCIeType,
+		Health:       string(raw.Status.Health),
+	}
+	if raw.PCIeInterface.LanesInUse != nil {
+		d.LanesInUse = *raw.PCIeInterface.LanesInUse
+	}
+	if raw.PCIeInterface.MaxLanes != nil {
+		d.MaxLanes = *raw.PCIeInterface.MaxLanes
+	}
+	d.Degraded = d.LanesInUse > 0 && d.LanesInUse < d.MaxLanes
+
+	var functions []rawFunction
+	if raw.PCIeFunctions != "" {
+		functions, err = query.GetMembers[rawFunction](
+			client,
+			raw.PCIeFunctions.String(),
+		)
+		if err != nil {
+			return d, err
+		}
+	} else {
+		for _, link := range raw.Links.PCIeFunctions.ToStrings() {
+			var f rawFunction
+			err = query.GetJSON(
+				client,
+				link,
+				&f,
+			)
+			if err != nil {
+				return d, err
+			}
+			functions = append(
+				functions,
+				f,
+			)
+		}
+	}
+	for _, f := range functions {
+		d.Functions = append(
+			d.Functions,
+			Function{
+				ID:                f.ID,
+				DeviceClass:       f.DeviceClass,
+				ClassCode:         f.ClassCode,
+				VendorID:          f.VendorID,
+				DeviceID:          f.DeviceID,
+				SubsystemVendorID: f.SubsystemVendorID,
+				SubsystemID:       f.SubsystemID,
+			},
+		)
+	}
+	return d, nil
+}
+
+// slot returns the label of a device's slot, or its type and ordinal.
+func slot(raw rawDevice) string {
+	location := raw.Slot.Location.PartLocation
+	if location.ServiceLabel != "" {
+		return location.ServiceLabel
+	}
+	if location.LocationOrdinalValue != nil {
+		return fmt.Sprintf(
+			"%s %d",
+			location.LocationType,
+			*location.LocationOrdinalValue,
+		)
+	}
+	return ""
+}
+
+// degraded describes a device whose link is degraded.
+func degraded(d Device) string {
+	name := d.Name
+	if d.Slot != "" {
+		name = fmt.Sprintf(
+			"%s (%s)",
+			name,
+			d.Slot,
+		)
+	}
+	return fmt.Sprintf(
+		"%s: x%d of x%d",
+		name,
+		d.LanesInUse,
+		d.MaxLanes,
+	)
+}
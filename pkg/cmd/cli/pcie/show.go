@@ -0,0 +1,196 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package pcie
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Cray-HPE/gru/internal/natural"
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewShowCommand creates the `pcie` subcommand for `show`.
+func NewShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "pcie host [...host]",
+		Short: "PCIe devices and functions",
+		Long: `Show the Server's PCIe devices with their slot, firmware, link width and speed (in use and maximum), and
+their functions' vendor, device, and class codes. Devices whose link trained at fewer lanes than they support are
+listed as degraded.
+
+--degraded only shows degraded devices, and exits non-zero if there are any.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := query.Async(
+				getPCIe,
+				hosts,
+			)
+
+			var failed []string
+			if v.GetBool("degraded") {
+				for host, result := range content {
+					p := result.(PCIe)
+					var devices []Device
+					for _, d := range p.Devices {
+						if d.Degraded {
+							devices = append(
+								devices,
+								d,
+							)
+						}
+					}
+					p.Devices = devices
+					content[host] = p
+					if len(p.Degraded) > 0 || p.Error != nil {
+						failed = append(
+							failed,
+							host,
+						)
+					}
+				}
+			}
+			cli.PrettyPrint(content)
+
+			if len(failed) > 0 {
+				sort.Strings(failed)
+				cmd.CheckError(
+					fmt.Errorf(
+						"%d host(s) degraded: %s",
+						len(failed),
+						strings.Join(
+							failed,
+							" ",
+						),
+					),
+				)
+			}
+		},
+	}
+	c.PersistentFlags().Bool(
+		"degraded",
+		false,
+		"Only show devices with a degraded link width, and exit non-zero if there are any",
+	)
+	return c
+}
+
+func getPCIe(host string) interface{} {
+	p := PCIe{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		p.Error = err
+		return p
+	}
+	defer c.Logout()
+	service := c.Service
+
+	systems, err := service.Systems()
+	if err != nil || len(systems) < 1 {
+		p.Error = err
+		return p
+	}
+	links, err := deviceLinks(
+		c,
+		systems[0].ODataID,
+	)
+	if err != nil {
+		p.Error = err
+		return p
+	}
+	chassis, err := service.Chassis()
+	if err != nil {
+		p.Error = err
+		return p
+	}
+	for _, ch := range chassis {
+		found, err := deviceLinks(
+			c,
+			ch.ODataID,
+		)
+		if err != nil {
+			p.Error = err
+			return p
+		}
+		links = append(
+			links,
+			found...,
+		)
+	}
+
+	seen := make(map[string]bool)
+	for _, link := range links {
+		link = strings.TrimRight(
+			link,
+			"/",
+		)
+		if seen[link] {
+			continue
+		}
+		seen[link] = true
+		d, err := getDevice(
+			c,
+			link,
+		)
+		if err != nil {
+			p.Error = err
+			return p
+		}
+		p.Devices = append(
+			p.Devices,
+			d,
+		)
+	}
+
+	sort.SliceStable(p.Devices, func(i, j int) bool {
+		return natural.Less(
+			p.Devices[i].Slot,
+			p.Devices[j].Slot,
+		)
+	})
+	for _, d := range p.Devices {
+		if d.Degraded {
+			p.Degraded = append(
+				p.Degraded,
+				degraded(d),
+			)
+		}
+	}
+	return p
+}
@@ -29,8 +29,12 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"reflect"
 	"sort"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
@@ -190,8 +194,21 @@ func structPrint(value reflect.Value) {
 			}
 			if reflect.TypeOf(value.Field(i).Interface()).Kind() == reflect.Slice {
 
+				if value.Field(i).Len() == 0 {
+
+					continue
+
+				}
+
+				keyPrint(
+					typeOfS.Field(i).Name,
+					"\t",
+				)
+
 				slicePrint(value.Field(i))
 
+				continue
+
 			}
 
 			keyValuePrint(
@@ -204,7 +221,270 @@ func structPrint(value reflect.Value) {
 	}
 }
 
-// PrettyPrint prints output in a human-readable manner, unless a specific format is given (e.g. `--json` or `--yaml`).
+// tableSection is a table of one row type; the content of each host itself (field -1), or the elements of a slice of
+// structs, either the content itself or one of its fields.
+type tableSection struct {
+	title   string
+	field   int
+	rowType reflect.Type
+}
+
+// tableSections returns the tables content of a type is printed as. A struct with slice of structs fields is printed as
+// a table of its other fields, if it has any, followed by a table per slice of structs field.
+func tableSections(t reflect.Type) []tableSection {
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Struct:
+		return []tableSection{
+			{
+				field:   -1,
+				rowType: t.Elem(),
+			},
+		}
+	case t.Kind() != reflect.Struct:
+		return nil
+	}
+	var slices []tableSection
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct {
+			slices = append(
+				slices,
+				tableSection{
+					title:   field.Name,
+					field:   i,
+					rowType: field.Type.Elem(),
+				},
+			)
+		}
+	}
+	if len(slices) > 0 && len(tableColumns(t)) == 0 {
+		return slices
+	}
+	return append(
+		[]tableSection{
+			{
+				title:   t.Name(),
+				field:   -1,
+				rowType: t,
+			},
+		},
+		slices...,
+	)
+}
+
+// rows returns the rows of a host's content in a section.
+func (s tableSection) rows(value reflect.Value) []reflect.Value {
+	if s.field >= 0 {
+		value = value.Field(s.field)
+	}
+	if value.Kind() != reflect.Slice {
+		return []reflect.Value{value}
+	}
+	var rows []reflect.Value
+	for i := 0; i < value.Len(); i++ {
+		rows = append(
+			rows,
+			value.Index(i),
+		)
+	}
+	return rows
+}
+
+// tableColumns returns the names of the fields of a row type that have a single value or a list of values, errors are
+// excluded.
+func tableColumns(t reflect.Type) []string {
+	var columns []string
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		switch field.Type.Kind() {
+		case reflect.Slice:
+			if field.Type.Elem().Kind() == reflect.Struct {
+				continue
+			}
+		case reflect.Map, reflect.Struct, reflect.Pointer:
+			continue
+		}
+		if field.Type == errorType {
+			continue
+		}
+		columns = append(
+			columns,
+			field.Name,
+		)
+	}
+	return columns
+}
+
+// tableCell formats a field of a row, the values of a list are comma delimited.
+func tableCell(field reflect.Value) string {
+	if field.Kind() != reflect.Slice {
+		return fmt.Sprintf(
+			"%v",
+			field.Interface(),
+		)
+	}
+	values := make(
+		[]string,
+		0,
+		field.Len(),
+	)
+	for i := 0; i < field.Len(); i++ {
+		values = append(
+			values,
+			fmt.Sprintf(
+				"%v",
+				field.Index(i).Interface(),
+			),
+		)
+	}
+	return strings.Join(
+		values,
+		", ",
+	)
+}
+
+// rowError returns the error of a row, if it has one.
+func rowError(row reflect.Value) error {
+	if row.Kind() != reflect.Struct {
+		return nil
+	}
+	e := row.FieldByName("Error")
+	if !e.IsValid() || e.Kind() != reflect.Interface || e.IsNil() {
+		return nil
+	}
+	err, _ := e.Interface().(error)
+	return err
+}
+
+// tablePrint prints content as tables with a row per host, or per element when a host's content is (or contains) a
+// slice of structs. The tables are chosen from the type of the content, the same for every host. A host that failed is
+// a row with only its error in the first table, errors are printed in a last column.
+func tablePrint(w io.Writer, content map[string]interface{}, keys []string) {
+	var contentType reflect.Type
+	for _, k := range keys {
+		if content[k] != nil {
+			contentType = reflect.TypeOf(content[k])
+			break
+		}
+	}
+	if contentType == nil {
+		return
+	}
+	sections := tableSections(contentType)
+
+	type tableRow struct {
+		host  string
+		value reflect.Value
+		err   error
+	}
+	printed := false
+	for i, section := range sections {
+		var rows []tableRow
+		errored := false
+		for _, k := range keys {
+			value := reflect.ValueOf(content[k])
+			if err := rowError(value); err != nil || !value.IsValid() || value.Type() != contentType {
+				if i == 0 && err != nil {
+					errored = true
+					rows = append(
+						rows,
+						tableRow{
+							host: k,
+							err:  err,
+						},
+					)
+				}
+				continue
+			}
+			for _, row := range section.rows(value) {
+				err := rowError(row)
+				errored = errored || err != nil
+				rows = append(
+					rows,
+					tableRow{
+						host:  k,
+						value: row,
+						err:   err,
+					},
+				)
+			}
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		if printed {
+			fmt.Fprintln(w)
+		}
+		printed = true
+		if len(sections) > 1 {
+			fmt.Fprintf(
+				w,
+				"%s:\n",
+				section.title,
+			)
+		}
+		tw := tabwriter.NewWriter(
+			w,
+			0,
+			0,
+			2,
+			' ',
+			0,
+		)
+		columns := tableColumns(section.rowType)
+		header := append(
+			[]string{"HOST"},
+			columns...,
+		)
+		if errored {
+			header = append(
+				header,
+				"ERROR",
+			)
+		}
+		fmt.Fprintln(
+			tw,
+			strings.ToUpper(
+				strings.Join(
+					header,
+					"\t",
+				),
+			),
+		)
+		for _, row := range rows {
+			cells := []string{row.host}
+			for _, column := range columns {
+				cell := ""
+				if row.err == nil {
+					cell = tableCell(row.value.FieldByName(column))
+				}
+				cells = append(
+					cells,
+					cell,
+				)
+			}
+			if row.err != nil {
+				cells = append(
+					cells,
+					row.err.Error(),
+				)
+			}
+			fmt.Fprintln(
+				tw,
+				strings.Join(
+					cells,
+					"\t",
+				),
+			)
+		}
+		tw.Flush()
+	}
+}
+
+// PrettyPrint prints output in a human-readable manner, unless a specific format is given (e.g. `--json`, `--yaml`,
+// or `--table`).
 func PrettyPrint(content map[string]interface{}) {
 	if viper.GetBool("json") {
 
@@ -261,6 +541,15 @@ func PrettyPrint(content map[string]interface{}) {
 
 		sort.Strings(keys)
 
+		if viper.GetBool("table") {
+			tablePrint(
+				os.Stdout,
+				content,
+				keys,
+			)
+			return
+		}
+
 		for _, k := range keys {
 
 			fmt.Printf(
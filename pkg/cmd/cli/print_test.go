@@ -0,0 +1,139 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type testDIMM struct {
+	Slot        string
+	CapacityMiB int
+}
+
+type testMemory struct {
+	TotalCapacityMiB int
+	Unhealthy        []string
+	DIMMs            []testDIMM
+	Error            error
+}
+
+type testNetwork struct {
+	Interfaces []testDIMM
+	Ports      []testDIMM
+	Error      error
+}
+
+// tableLines returns the lines tablePrint prints for content, with runs of spaces collapsed.
+func tableLines(content map[string]interface{}) []string {
+	var b bytes.Buffer
+	keys := []string{}
+	for k := range content {
+		keys = append(
+			keys,
+			k,
+		)
+	}
+	sort.Strings(keys)
+	tablePrint(
+		&b,
+		content,
+		keys,
+	)
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(b.String(), "\n"), "\n") {
+		lines = append(
+			lines,
+			strings.Join(
+				strings.Fields(line),
+				" ",
+			),
+		)
+	}
+	return lines
+}
+
+func TestTablePrintFailedAndSuccessfulHosts(t *testing.T) {
+	content := map[string]interface{}{
+		"a": testMemory{
+			Error: errors.New("no memory"),
+		},
+		"b": testMemory{
+			TotalCapacityMiB: 32768,
+			Unhealthy:        []string{"DIMM1", "DIMM2"},
+			DIMMs: []testDIMM{
+				{Slot: "DIMM0", CapacityMiB: 16384},
+				{Slot: "DIMM1", CapacityMiB: 16384},
+			},
+		},
+	}
+	want := []string{
+		"testMemory:",
+		"HOST TOTALCAPACITYMIB UNHEALTHY ERROR",
+		"a no memory",
+		"b 32768 DIMM1, DIMM2",
+		"",
+		"DIMMs:",
+		"HOST SLOT CAPACITYMIB",
+		"b DIMM0 16384",
+		"b DIMM1 16384",
+	}
+	got := tableLines(content)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestTablePrintSlicesOnly(t *testing.T) {
+	content := map[string]interface{}{
+		"a": testNetwork{
+			Error: errors.New("unreachable"),
+		},
+		"b": testNetwork{
+			Ports: []testDIMM{
+				{Slot: "1", CapacityMiB: 1},
+			},
+		},
+	}
+	want := []string{
+		"Interfaces:",
+		"HOST SLOT CAPACITYMIB ERROR",
+		"a unreachable",
+		"",
+		"Ports:",
+		"HOST SLOT CAPACITYMIB",
+		"b 1 1",
+	}
+	got := tableLines(content)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,163 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package proc
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/natural"
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// Accelerators represents a host's GPUs and other accelerators.
+type Accelerators struct {
+	Accelerators []Accelerator `json:"accelerators" yaml:"accelerators"`
+	Error        error         `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Accelerator represents a single processor that is not a CPU; a GPU, FPGA, DSP, or other accelerator.
+type Accelerator struct {
+	ID           string `json:"id" yaml:"id"`
+	Type         string `json:"type" yaml:"type"`
+	Manufacturer string `json:"manufacturer" yaml:"manufacturer"`
+	Model        string `json:"model" yaml:"model"`
+	Socket       string `json:"socket" yaml:"socket"`
+	MemoryMiB    int    `json:"memoryMiB" yaml:"memory_mib"`
+	Firmware     string `json:"firmware" yaml:"firmware"`
+	Health       string `json:"health" yaml:"health"`
+	State        string `json:"state" yaml:"state"`
+}
+
+// acceleratorTypes are the ProcessorTypes listed by `show accelerators`.
+var acceleratorTypes = map[string]bool{
+	"GPU":         true,
+	"FPGA":        true,
+	"DSP":         true,
+	"Accelerator": true,
+}
+
+// NewShowAcceleratorsCommand creates the `accelerators` subcommand for `show`.
+func NewShowAcceleratorsCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "accelerators host [...host]",
+		Short: "GPU and accelerator information",
+		Long:  `Show the Server's GPUs, FPGAs, and other accelerators with their model, memory, firmware, and health.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+			content := query.Async(
+				getAccelerators,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	return c
+}
+
+func getAccelerators(host string) interface{} {
+	a := Accelerators{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		a.Error = err
+		return a
+	}
+	defer c.Logout()
+
+	systems, err := c.Service.Systems()
+	if err != nil || len(systems) < 1 {
+		a.Error = err
+		return a
+	}
+	processors, err := systems[0].Processors()
+	if err != nil {
+		a.Error = err
+		return a
+	}
+
+	for _, p := range processors {
+		if !acceleratorTypes[string(p.ProcessorType)] {
+			continue
+		}
+		accelerator := Accelerator{
+			ID:           p.ID,
+			Type:         string(p.ProcessorType),
+			Manufacturer: strings.TrimSpace(p.Manufacturer),
+			Model:        strings.TrimSpace(p.Model),
+			Socket:       strings.TrimSpace(p.Socket),
+			MemoryMiB:    acceleratorMemory(c, p),
+			Firmware:     strings.TrimSpace(p.FirmwareVersion),
+			Health:       string(p.Status.Health),
+			State:        string(p.Status.State),
+		}
+		a.Accelerators = append(
+			a.Accelerators,
+			accelerator,
+		)
+	}
+	sort.SliceStable(a.Accelerators, func(i, j int) bool {
+		return natural.Less(
+			a.Accelerators[i].ID,
+			a.Accelerators[j].ID,
+		)
+	})
+	return a
+}
+
+// acceleratorMemory returns the memory of an accelerator in MiB; its ProcessorMemory, its MemorySummary (which gofish
+// does not decode for processors), or the capacity of its linked memory.
+func acceleratorMemory(c common.Client, p *redfish.Processor) int {
+	total := 0
+	for _, m := range p.ProcessorMemory {
+		total += m.CapacityMiB
+	}
+	if total > 0 {
+		return total
+	}
+	var raw struct {
+		MemorySummary struct {
+			TotalMemorySizeMiB int
+		}
+	}
+	if query.GetJSON(c, p.ODataID, &raw) == nil && raw.MemorySummary.TotalMemorySizeMiB > 0 {
+		return raw.MemorySummary.TotalMemorySizeMiB
+	}
+	memory, err := p.Memory()
+	if err != nil {
+		return 0
+	}
+	for _, m := range memory {
+		total += m.CapacityMiB
+	}
+	return total
+}
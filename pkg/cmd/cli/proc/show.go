@@ -31,6 +31,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish/redfish"
 
 	"github.com/Cray-HPE/gru/internal/query"
 	"github.com/Cray-HPE/gru/pkg/auth"
@@ -42,7 +43,8 @@ func NewShowCommand() *cobra.Command {
 	c := &cobra.Command{
 		Use: "proc host [...host]",
 		Short: "Processor information",
-		Long: `Show the Server's processors, a full list with their core count, model, architecture, and serial numbers.`,
+		Long: `Show the Server's processors, a full list with their core count, model, architecture, and serial numbers.
+GPUs and other accelerators are shown by 'gru show accelerators'.`,
 		Run: func(c *cobra.Command, args []string) {
 			hosts := cli.ParseHosts(args)
 			content := query.Async(
@@ -101,6 +103,9 @@ func getProcessors(host string) interface{} {
 		)
 	} else {
 		for i := range systemProcessors {
+			if !isCPU(systemProcessors[i]) {
+				continue
+			}
 			processor := Processor{
 				Architecture: strings.TrimSpace(
 					fmt.Sprintf(
@@ -122,3 +127,8 @@ func getProcessors(host string) interface{} {
 	}
 	return foundProcessors
 }
+
+// isCPU returns whether a processor is a CPU, processors without a ProcessorType are assumed to be.
+func isCPU(p *redfish.Processor) bool {
+	return p.ProcessorType == redfish.CPUProcessorType || p.ProcessorType == ""
+}
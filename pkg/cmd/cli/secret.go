@@ -0,0 +1,106 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ReadStdinLines reads exactly n lines from os.Stdin, trailing carriage returns and newlines are removed.
+func ReadStdinLines(n int) ([]string, error) {
+	var lines []string
+	reader := bufio.NewReader(os.Stdin)
+	for len(lines) < n {
+		line, err := reader.ReadString('\n')
+		line = strings.TrimRight(
+			line,
+			"\r\n",
+		)
+		if line != "" || err == nil {
+			lines = append(
+				lines,
+				line,
+			)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if len(lines) != n {
+		return lines, fmt.Errorf(
+			"expected %d line(s) on stdin, received %d",
+			n,
+			len(lines),
+		)
+	}
+	return lines, nil
+}
+
+// ReadSecret prompts on os.Stderr and reads a single line from the terminal with echo disabled.
+// An error is returned if echo can not be disabled, the secret is never read while it would be echoed.
+func ReadSecret(prompt string) (string, error) {
+	if isInputFromPipe() {
+		return "", fmt.Errorf("can not prompt for a secret, stdin is not a terminal")
+	}
+	if err := stty("-echo"); err != nil {
+		return "", fmt.Errorf(
+			"unable to disable terminal echo: %v",
+			err,
+		)
+	}
+	defer func() {
+		_ = stty("echo")
+		fmt.Fprintln(os.Stderr)
+	}()
+
+	fmt.Fprint(
+		os.Stderr,
+		prompt,
+	)
+	secret, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && secret == "" {
+		return "", err
+	}
+	return strings.TrimRight(
+		secret,
+		"\r\n",
+	), nil
+}
+
+// stty applies the given setting to the terminal attached to os.Stdin.
+func stty(setting string) error {
+	c := exec.Command(
+		"stty",
+		setting,
+	)
+	c.Stdin = os.Stdin
+	return c.Run()
+}
@@ -0,0 +1,91 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package secureboot
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewEnableCommand creates the `enable` subcommand for `secureboot`.
+func NewEnableCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "enable host [...host]",
+		Short: "Enable Secure Boot",
+		Long: `Enables UEFI Secure Boot on the target machine(s), this only takes effect on the next boot.
+Secure Boot can only be enabled in UEFI boot mode.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+			content := set.Async(
+				setSecureBootEnable,
+				hosts,
+				true,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	set.AddFlags(c)
+	return c
+}
+
+// NewDisableCommand creates the `disable` subcommand for `secureboot`.
+func NewDisableCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "disable host [...host]",
+		Short: "Disable Secure Boot",
+		Long:  `Disables UEFI Secure Boot on the target machine(s), this only takes effect on the next boot.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+			content := set.Async(
+				setSecureBootEnable,
+				hosts,
+				false,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	set.AddFlags(c)
+	return c
+}
+
+// setSecureBootEnable sets SecureBootEnable on a host and returns the resulting Secure Boot state.
+func setSecureBootEnable(host string, enable interface{}) interface{} {
+	sb, err := getSecureBoot(host)
+	if err != nil {
+		return SecureBoot{Error: err}
+	}
+
+	sb.SecureBootEnable = enable.(bool)
+	err = sb.Update()
+	secureBoot := newSecureBoot(sb)
+	if err != nil {
+		secureBoot.Error = err
+	}
+	return secureBoot
+}
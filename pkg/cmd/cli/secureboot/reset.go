@@ -0,0 +1,141 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package secureboot
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// KeyReset represents the result of resetting Secure Boot keys.
+type KeyReset struct {
+	ResetKeysType redfish.ResetKeysType `json:"resetKeysType" yaml:"reset_keys_type"`
+	Database      string                `json:"database,omitempty" yaml:"database,omitempty"`
+	Error         error                 `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewResetKeysCommand creates the `reset-keys` subcommand for `secureboot`.
+func NewResetKeysCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "reset-keys host [...host]",
+		Short: "Reset the Secure Boot keys",
+		Long: `Resets the UEFI Secure Boot key databases (PK, KEK, db, dbx) to their default keys.
+Deleting all keys, or only the PK, puts the system in Setup Mode.
+A single database may be reset if the BMC supports SecureBootDatabases.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			reset := KeyReset{
+				ResetKeysType: redfish.ResetKeysType(v.GetString("type")),
+				Database:      v.GetString("database"),
+			}
+			switch reset.ResetKeysType {
+			case redfish.ResetAllKeysToDefaultResetKeysType, redfish.DeleteAllKeysResetKeysType:
+			case redfish.DeletePKResetKeysType:
+				if reset.Database != "" {
+					cmd.CheckError(fmt.Errorf("%s can not be used with --database", reset.ResetKeysType))
+				}
+			default:
+				cmd.CheckError(fmt.Errorf("invalid reset type: %s", reset.ResetKeysType))
+			}
+
+			content := set.Async(
+				resetKeys,
+				hosts,
+				reset,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	c.PersistentFlags().String(
+		"type",
+		string(redfish.ResetAllKeysToDefaultResetKeysType),
+		fmt.Sprintf(
+			"Reset type: %s, %s, or %s",
+			redfish.ResetAllKeysToDefaultResetKeysType,
+			redfish.DeleteAllKeysResetKeysType,
+			redfish.DeletePKResetKeysType,
+		),
+	)
+	c.PersistentFlags().String(
+		"database",
+		"",
+		"Only reset the given Secure Boot database (e.g. db, dbx, KEK, or PK)",
+	)
+	set.AddFlags(c)
+	return c
+}
+
+// resetKeys resets the Secure Boot keys, or a single Secure Boot database, on a host.
+func resetKeys(host string, data interface{}) interface{} {
+	reset := data.(KeyReset)
+
+	sb, err := getSecureBoot(host)
+	if err != nil {
+		reset.Error = err
+		return reset
+	}
+
+	if reset.Database == "" {
+		err = sb.ResetKeys(reset.ResetKeysType)
+		if err != nil {
+			reset.Error = err
+		}
+		return reset
+	}
+
+	databases, err := getDatabases(sb)
+	if err != nil {
+		reset.Error = err
+		return reset
+	}
+	for _, db := range databases {
+		if db.DatabaseID == reset.Database || db.ID == reset.Database {
+			err = db.ResetKeys(reset.ResetKeysType)
+			if err != nil {
+				reset.Error = err
+			}
+			return reset
+		}
+	}
+	reset.Error = fmt.Errorf(
+		"secure boot database not found: %s",
+		reset.Database,
+	)
+	return reset
+}
@@ -0,0 +1,198 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package secureboot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/pkg/auth"
+)
+
+// SecureBoot represents the UEFI Secure Boot state of a system. Only Databases and Error are omitted on empty.
+type SecureBoot struct {
+	SecureBootEnable      bool                              `json:"secureBootEnable" yaml:"secure_boot_enable"`
+	SecureBootCurrentBoot redfish.SecureBootCurrentBootType `json:"secureBootCurrentBoot" yaml:"secure_boot_current_boot"`
+	SecureBootMode        redfish.SecureBootModeType        `json:"secureBootMode" yaml:"secure_boot_mode"`
+	Databases             []Database                        `json:"databases,omitempty" yaml:"databases,omitempty"`
+	Error                 error                             `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Database represents a single UEFI Secure Boot database (e.g. db, dbx, KEK, or PK).
+type Database struct {
+	DatabaseID   string        `json:"databaseID" yaml:"database_id"`
+	Signatures   int           `json:"signatures" yaml:"signatures"`
+	Certificates []Certificate `json:"certificates,omitempty" yaml:"certificates,omitempty"`
+}
+
+// Certificate represents a certificate enrolled in a UEFI Secure Boot database.
+type Certificate struct {
+	Subject        string `json:"subject" yaml:"subject"`
+	Issuer         string `json:"issuer" yaml:"issuer"`
+	Fingerprint    string `json:"fingerprint" yaml:"fingerprint"`
+	ValidNotBefore string `json:"validNotBefore" yaml:"valid_not_before"`
+	ValidNotAfter  string `json:"validNotAfter" yaml:"valid_not_after"`
+}
+
+// Compliant reports whether Secure Boot is enabled and was enforced during the current boot.
+func (s SecureBoot) Compliant() bool {
+	return s.Error == nil && s.SecureBootEnable && s.SecureBootCurrentBoot == redfish.EnabledSecureBootCurrentBootType
+}
+
+// NewCommand creates the `secureboot` subcommand.
+func NewCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "secureboot",
+		Short: "UEFI Secure Boot",
+		Long:  `Show and manage the UEFI Secure Boot state and key databases of a host`,
+	}
+	c.AddCommand(
+		NewDisableCommand(),
+		NewEnableCommand(),
+		NewResetKeysCommand(),
+		NewShowCommand(),
+	)
+	return c
+}
+
+// getSecureBoot returns the SecureBoot resource of the host's first system.
+func getSecureBoot(host string) (*redfish.SecureBoot, error) {
+	c, err := auth.Connection(host)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Logout()
+
+	service := c.Service
+
+	systems, err := service.Systems()
+	if err != nil {
+		return nil, err
+	}
+	if len(systems) < 1 {
+		return nil, fmt.Errorf("no systems found")
+	}
+
+	sb, err := systems[0].SecureBoot()
+	if err != nil {
+		return nil, err
+	}
+	if sb == nil {
+		return nil, fmt.Errorf("the BMC does not advertise a SecureBoot resource")
+	}
+	return sb, nil
+}
+
+// getDatabases returns the SecureBootDatabases of a SecureBoot resource, gofish does not expose this link so
+// the resource is read directly. An empty slice is returned if the BMC does not support SecureBootDatabases.
+func getDatabases(sb *redfish.SecureBoot) ([]*redfish.SecureBootDatabase, error) {
+	client := sb.GetClient()
+	resp, err := client.Get(sb.ODataID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var links struct {
+		SecureBootDatabases common.Link
+	}
+	err = json.NewDecoder(resp.Body).Decode(&links)
+	if err != nil {
+		return nil, err
+	}
+	if links.SecureBootDatabases.String() == "" {
+		return []*redfish.SecureBootDatabase{}, nil
+	}
+	return redfish.ListReferencedSecureBootDatabases(
+		client,
+		links.SecureBootDatabases.String(),
+	)
+}
+
+// newSecureBoot creates a SecureBoot from a redfish.SecureBoot.
+func newSecureBoot(sb *redfish.SecureBoot) SecureBoot {
+	return SecureBoot{
+		SecureBootEnable:      sb.SecureBootEnable,
+		SecureBootCurrentBoot: sb.SecureBootCurrentBoot,
+		SecureBootMode:        sb.SecureBootMode,
+	}
+}
+
+// newDatabase creates a Database from a redfish.SecureBootDatabase, including its certificates.
+func newDatabase(db *redfish.SecureBootDatabase) (Database, error) {
+	database := Database{
+		DatabaseID: strings.TrimSpace(db.DatabaseID),
+	}
+	if database.DatabaseID == "" {
+		database.DatabaseID = db.ID
+	}
+
+	certificates, err := db.Certificates()
+	if err != nil {
+		return database, err
+	}
+	for _, cert := range certificates {
+		database.Certificates = append(
+			database.Certificates,
+			Certificate{
+				Subject:        identifier(cert.Subject),
+				Issuer:         identifier(cert.Issuer),
+				Fingerprint:    strings.TrimSpace(cert.Fingerprint),
+				ValidNotBefore: cert.ValidNotBefore,
+				ValidNotAfter:  cert.ValidNotAfter,
+			},
+		)
+	}
+
+	signatures, err := db.Signatures()
+	if err != nil {
+		return database, err
+	}
+	database.Signatures = len(signatures)
+
+	return database, nil
+}
+
+// identifier returns the most descriptive name of a certificate identifier.
+func identifier(id redfish.CertificateIdentifier) string {
+	if id.DisplayString != "" {
+		return strings.TrimSpace(id.DisplayString)
+	}
+	if id.Organization != "" && id.CommonName != "" {
+		return fmt.Sprintf(
+			"%s (%s)",
+			strings.TrimSpace(id.CommonName),
+			strings.TrimSpace(id.Organization),
+		)
+	}
+	return strings.TrimSpace(id.CommonName)
+}
@@ -0,0 +1,133 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package secureboot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewShowCommand creates the `show` subcommand for `secureboot`.
+func NewShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "show host [...host]",
+		Short: "Secure Boot information",
+		Long: `Show SecureBootEnable, SecureBootCurrentBoot, and SecureBootMode for the target machine(s).
+The certificates of each Secure Boot database (e.g. db, dbx, KEK, and PK) are listed if the BMC supports SecureBootDatabases.
+
+With --check, exits non-zero if any host does not have Secure Boot enabled and enforced during the current boot.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := query.Async(
+				getSecureBootInformation,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+
+			if v.GetBool("check") {
+				var nonCompliant []string
+				for host, result := range content {
+					if !result.(SecureBoot).Compliant() {
+						nonCompliant = append(
+							nonCompliant,
+							host,
+						)
+					}
+				}
+				if len(nonCompliant) > 0 {
+					cmd.CheckError(
+						fmt.Errorf(
+							"%d of %d host(s) do not have Secure Boot enabled: %s",
+							len(nonCompliant),
+							len(content),
+							strings.Join(
+								nonCompliant,
+								" ",
+							),
+						),
+					)
+				}
+			}
+		},
+	}
+	c.PersistentFlags().Bool(
+		"check",
+		false,
+		"Exit non-zero if any host does not have Secure Boot enabled",
+	)
+	c.PersistentFlags().Bool(
+		"no-databases",
+		false,
+		"Do not list the Secure Boot databases and their certificates",
+	)
+	return c
+}
+
+// getSecureBootInformation retrieves the Secure Boot state and databases of a host.
+func getSecureBootInformation(host string) interface{} {
+	v := viper.GetViper()
+
+	sb, err := getSecureBoot(host)
+	if err != nil {
+		return SecureBoot{Error: err}
+	}
+	secureBoot := newSecureBoot(sb)
+
+	if v.GetBool("no-databases") {
+		return secureBoot
+	}
+
+	databases, err := getDatabases(sb)
+	if err != nil {
+		secureBoot.Error = err
+		return secureBoot
+	}
+	for _, db := range databases {
+		database, err := newDatabase(db)
+		if err != nil {
+			secureBoot.Error = err
+			return secureBoot
+		}
+		secureBoot.Databases = append(
+			secureBoot.Databases,
+			database,
+		)
+	}
+	return secureBoot
+}
@@ -0,0 +1,166 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package sensor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish/common"
+
+	"github.com/Cray-HPE/gru/pkg/cmd"
+)
+
+// Alert levels of a Reading, in increasing severity.
+const (
+	Warning  = "warning"
+	Critical = "critical"
+	Fatal    = "fatal"
+)
+
+// Reading represents a single sensor reading and its thresholds. Thresholds are shown as lower..upper, either side is
+// empty when the sensor does not have that threshold. Alert is the most severe threshold the reading is outside of,
+// or the sensor's health when it is not OK.
+type Reading struct {
+	Chassis  string  `json:"chassis" yaml:"chassis"`
+	Name     string  `json:"name" yaml:"name"`
+	Type     string  `json:"type,omitempty" yaml:"type,omitempty"`
+	Reading  float64 `json:"reading" yaml:"reading"`
+	Units    string  `json:"units,omitempty" yaml:"units,omitempty"`
+	Warning  string  `json:"warning,omitempty" yaml:"warning,omitempty"`
+	Critical string  `json:"critical,omitempty" yaml:"critical,omitempty"`
+	Fatal    string  `json:"fatal,omitempty" yaml:"fatal,omitempty"`
+	Health   string  `json:"health,omitempty" yaml:"health,omitempty"`
+	Alert    string  `json:"alert,omitempty" yaml:"alert,omitempty"`
+}
+
+// thresholds represents the lower and upper warning, critical, and fatal thresholds of a sensor. Thresholds the
+// sensor does not have are nil.
+type thresholds struct {
+	LowerWarning, UpperWarning   *float64
+	LowerCritical, UpperCritical *float64
+	LowerFatal, UpperFatal       *float64
+}
+
+// newReading creates a Reading from a raw reading and its thresholds, and evaluates its alert level.
+func newReading(chassis string, name string, readingType string, reading *float64, units string, t thresholds, status common.Status) Reading {
+	r := Reading{
+		Chassis:  chassis,
+		Name:     name,
+		Type:     readingType,
+		Units:    units,
+		Warning:  thresholdRange(t.LowerWarning, t.UpperWarning),
+		Critical: thresholdRange(t.LowerCritical, t.UpperCritical),
+		Fatal:    thresholdRange(t.LowerFatal, t.UpperFatal),
+		Health:   string(status.Health),
+	}
+	if reading != nil {
+		r.Reading = *reading
+		switch {
+		case outside(r.Reading, t.LowerFatal, t.UpperFatal):
+			r.Alert = Fatal
+		case outside(r.Reading, t.LowerCritical, t.UpperCritical):
+			r.Alert = Critical
+		case outside(r.Reading, t.LowerWarning, t.UpperWarning):
+			r.Alert = Warning
+		}
+	}
+	if r.Alert == "" && status.Health != "" && status.Health != common.OKHealth {
+		r.Alert = strings.ToLower(string(status.Health))
+	}
+	return r
+}
+
+// outside returns whether a reading has reached either of the given thresholds.
+func outside(reading float64, lower *float64, upper *float64) bool {
+	return (lower != nil && reading <= *lower) || (upper != nil && reading >= *upper)
+}
+
+// thresholdRange formats a lower and upper threshold as lower..upper.
+func thresholdRange(lower *float64, upper *float64) string {
+	if lower == nil && upper == nil {
+		return ""
+	}
+	var l, u string
+	if lower != nil {
+		l = fmt.Sprintf(
+			"%g",
+			*lower,
+		)
+	}
+	if upper != nil {
+		u = fmt.Sprintf(
+			"%g",
+			*upper,
+		)
+	}
+	return l + ".." + u
+}
+
+// alerting returns only the readings with an alert.
+func alerting(readings []Reading) []Reading {
+	var alerts []Reading
+	for _, r := range readings {
+		if r.Alert != "" {
+			alerts = append(
+				alerts,
+				r,
+			)
+		}
+	}
+	return alerts
+}
+
+// addAlertingFlag adds the `--alerting` flag to a command.
+func addAlertingFlag(c *cobra.Command) {
+	c.PersistentFlags().Bool(
+		"alerting",
+		false,
+		"Only show sensors outside of their thresholds (or unhealthy), and exit non-zero if there are any",
+	)
+}
+
+// checkAlerting exits non-zero if `--alerting` was given and any of the given hosts are alerting.
+func checkAlerting(alerts []string) {
+	if !viper.GetBool("alerting") || len(alerts) == 0 {
+		return
+	}
+	sort.Strings(alerts)
+	cmd.CheckError(
+		fmt.Errorf(
+			"%d host(s) alerting: %s",
+			len(alerts),
+			strings.Join(
+				alerts,
+				" ",
+			),
+		),
+	)
+}
@@ -0,0 +1,195 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package sensor
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish/common"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// Sensors represents the readings of every sensor in every chassis of a host.
+type Sensors struct {
+	Sensors []Reading `json:"sensors" yaml:"sensors"`
+	Error   error     `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// rawChassis is the subset of a raw chassis with links to its sensors and thermal information.
+type rawChassis struct {
+	ID               string `json:"Id"`
+	Sensors          common.Link
+	Thermal          common.Link
+	ThermalSubsystem common.Link
+}
+
+type rawThreshold struct {
+	Reading *float64
+}
+
+type rawSensor struct {
+	Name         string
+	Reading      *float64
+	ReadingType  string
+	ReadingUnits string
+	Status       common.Status
+	Thresholds   struct {
+		LowerCaution, UpperCaution   rawThreshold
+		LowerCritical, UpperCritical rawThreshold
+		LowerFatal, UpperFatal       rawThreshold
+	}
+}
+
+// NewShowCommand creates the `sensors` subcommand for `show`.
+func NewShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "sensors host [...host]",
+		Short: "Sensor readings",
+		Long: `Show every sensor of the Server's chassis (temperature, voltage, current, power, fan speed, etc.) with its
+warning (caution), critical, and fatal thresholds.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := query.Async(
+				getSensors,
+				hosts,
+			)
+
+			var alerts []string
+			if v.GetBool("alerting") {
+				for host, result := range content {
+					sensors := result.(Sensors)
+					sensors.Sensors = alerting(sensors.Sensors)
+					content[host] = sensors
+					if len(sensors.Sensors) > 0 || sensors.Error != nil {
+						alerts = append(
+							alerts,
+							host,
+						)
+					}
+				}
+			}
+			cli.PrettyPrint(content)
+			checkAlerting(alerts)
+		},
+	}
+	addAlertingFlag(c)
+	return c
+}
+
+func getSensors(host string) interface{} {
+	s := Sensors{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		s.Error = err
+		return s
+	}
+	defer c.Logout()
+
+	chassis, err := c.Service.Chassis()
+	if err != nil {
+		s.Error = err
+		return s
+	}
+	for _, ch := range chassis {
+		var raw rawChassis
+		err = query.GetJSON(
+			ch.GetClient(),
+			ch.ODataID,
+			&raw,
+		)
+		if err != nil {
+			s.Error = err
+			return s
+		}
+		readings, err := chassisSensors(
+			ch.GetClient(),
+			raw,
+			"",
+		)
+		if err != nil {
+			s.Error = err
+			return s
+		}
+		s.Sensors = append(
+			s.Sensors,
+			readings...,
+		)
+	}
+	return s
+}
+
+// chassisSensors returns the readings of a chassis' sensors, optionally only those of the given reading type.
+func chassisSensors(client common.Client, ch rawChassis, readingType string) ([]Reading, error) {
+	if ch.Sensors == "" {
+		return nil, nil
+	}
+
+	sensors, err := query.GetMembers[rawSensor](
+		client,
+		ch.Sensors.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var readings []Reading
+	for _, sensor := range sensors {
+		if readingType != "" && sensor.ReadingType != readingType {
+			continue
+		}
+		t := sensor.Thresholds
+		readings = append(
+			readings,
+			newReading(
+				ch.ID,
+				sensor.Name,
+				sensor.ReadingType,
+				sensor.Reading,
+				sensor.ReadingUnits,
+				thresholds{
+					LowerWarning:  t.LowerCaution.Reading,
+					UpperWarning:  t.UpperCaution.Reading,
+					LowerCritical: t.LowerCritical.Reading,
+					UpperCritical: t.UpperCritical.Reading,
+					LowerFatal:    t.LowerFatal.Reading,
+					UpperFatal:    t.UpperFatal.Reading,
+				},
+				sensor.Status,
+			),
+		)
+	}
+	return readings, nil
+}
@@ -0,0 +1,340 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package sensor
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish/common"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// Thermal represents the temperatures, fans, and fan redundancy of every chassis of a host.
+type Thermal struct {
+	Temperatures []Reading    `json:"temperatures" yaml:"temperatures"`
+	Fans         []Reading    `json:"fans" yaml:"fans"`
+	Redundancy   []Redundancy `json:"redundancy,omitempty" yaml:"redundancy,omitempty"`
+	Error        error        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Redundancy represents a redundancy group, e.g. of fans.
+type Redundancy struct {
+	Chassis   string `json:"chassis" yaml:"chassis"`
+	Name      string `json:"name" yaml:"name"`
+	Mode      string `json:"mode" yaml:"mode"`
+	MinNeeded int    `json:"minNeeded" yaml:"min_needed"`
+	Health    string `json:"health" yaml:"health"`
+}
+
+type rawThresholds struct {
+	LowerThresholdNonCritical, UpperThresholdNonCritical *float64
+	LowerThresholdCritical, UpperThresholdCritical       *float64
+	LowerThresholdFatal, UpperThresholdFatal             *float64
+}
+
+func (t rawThresholds) thresholds() thresholds {
+	return thresholds{
+		LowerWarning:  t.LowerThresholdNonCritical,
+		UpperWarning:  t.UpperThresholdNonCritical,
+		LowerCritical: t.LowerThresholdCritical,
+		UpperCritical: t.UpperThresholdCritical,
+		LowerFatal:    t.LowerThresholdFatal,
+		UpperFatal:    t.UpperThresholdFatal,
+	}
+}
+
+// rawThermal is the subset of a raw (deprecated) Thermal resource.
+type rawThermal struct {
+	Temperatures []struct {
+		rawThresholds
+		Name           string
+		ReadingCelsius *float64
+		Status         common.Status
+	}
+	Fans []struct {
+		rawThresholds
+		Name         string
+		FanName      string
+		Reading      *float64
+		ReadingUnits string
+		Status       common.Status
+	}
+	Redundancy []struct {
+		Name         string
+		Mode         string
+		MinNumNeeded int
+		Status       common.Status
+	}
+}
+
+// rawThermalSubsystem is the subset of a raw ThermalSubsystem resource.
+type rawThermalSubsystem struct {
+	Fans          common.Link
+	FanRedundancy []struct {
+		RedundancyType   string
+		MinNeededInGroup int
+		Status           common.Status
+	}
+}
+
+type rawFan struct {
+	Name         string
+	SpeedPercent struct {
+		Reading  *float64
+		SpeedRPM *float64
+	}
+	Status common.Status
+}
+
+// NewShowThermalCommand creates the `thermal` subcommand for `show`.
+func NewShowThermalCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "thermal host [...host]",
+		Short: "Temperatures and fans",
+		Long: `Show the Server's temperatures and fans with their warning, critical, and fatal thresholds, and the
+redundancy of its fans.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := query.Async(
+				getThermal,
+				hosts,
+			)
+
+			var alerts []string
+			if v.GetBool("alerting") {
+				for host, result := range content {
+					thermal := result.(Thermal)
+					thermal.Temperatures = alerting(thermal.Temperatures)
+					thermal.Fans = alerting(thermal.Fans)
+					var degraded []Redundancy
+					for _, r := range thermal.Redundancy {
+						if r.Health != "" && r.Health != string(common.OKHealth) {
+							degraded = append(
+								degraded,
+								r,
+							)
+						}
+					}
+					thermal.Redundancy = degraded
+					content[host] = thermal
+					if len(thermal.Temperatures) > 0 || len(thermal.Fans) > 0 || len(thermal.Redundancy) > 0 || thermal.Error != nil {
+						alerts = append(
+							alerts,
+							host,
+						)
+					}
+				}
+			}
+			cli.PrettyPrint(content)
+			checkAlerting(alerts)
+		},
+	}
+	addAlertingFlag(c)
+	return c
+}
+
+func getThermal(host string) interface{} {
+	t := Thermal{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		t.Error = err
+		return t
+	}
+	defer c.Logout()
+
+	chassis, err := c.Service.Chassis()
+	if err != nil {
+		t.Error = err
+		return t
+	}
+	for _, ch := range chassis {
+		var raw rawChassis
+		err = query.GetJSON(
+			ch.GetClient(),
+			ch.ODataID,
+			&raw,
+		)
+		if err == nil {
+			switch {
+			case raw.Thermal != "":
+				err = addThermal(
+					&t,
+					ch.GetClient(),
+					raw,
+				)
+			case raw.ThermalSubsystem != "":
+				err = addThermalSubsystem(
+					&t,
+					ch.GetClient(),
+					raw,
+				)
+			}
+		}
+		if err != nil {
+			t.Error = err
+			return t
+		}
+	}
+	return t
+}
+
+// addThermal adds the readings of a chassis' (deprecated) Thermal resource.
+func addThermal(t *Thermal, client common.Client, ch rawChassis) error {
+	var thermal rawThermal
+	err := query.GetJSON(
+		client,
+		ch.Thermal.String(),
+		&thermal,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, temperature := range thermal.Temperatures {
+		t.Temperatures = append(
+			t.Temperatures,
+			newReading(
+				ch.ID,
+				temperature.Name,
+				"Temperature",
+				temperature.ReadingCelsius,
+				"Cel",
+				temperature.thresholds(),
+				temperature.Status,
+			),
+		)
+	}
+	for _, fan := range thermal.Fans {
+		name := fan.Name
+		if name == "" {
+			name = fan.FanName
+		}
+		t.Fans = append(
+			t.Fans,
+			newReading(
+				ch.ID,
+				name,
+				"Fan",
+				fan.Reading,
+				fan.ReadingUnits,
+				fan.thresholds(),
+				fan.Status,
+			),
+		)
+	}
+	for _, redundancy := range thermal.Redundancy {
+		t.Redundancy = append(
+			t.Redundancy,
+			Redundancy{
+				Chassis:   ch.ID,
+				Name:      redundancy.Name,
+				Mode:      redundancy.Mode,
+				MinNeeded: redundancy.MinNumNeeded,
+				Health:    string(redundancy.Status.Health),
+			},
+		)
+	}
+	return nil
+}
+
+// addThermalSubsystem adds the readings of a chassis' ThermalSubsystem. The ThermalSubsystem does not have
+// temperature thresholds, those are read from the chassis' temperature sensors instead.
+func addThermalSubsystem(t *Thermal, client common.Client, ch rawChassis) error {
+	var subsystem rawThermalSubsystem
+	err := query.GetJSON(
+		client,
+		ch.ThermalSubsystem.String(),
+		&subsystem,
+	)
+	if err != nil {
+		return err
+	}
+
+	temperatures, err := chassisSensors(
+		client,
+		ch,
+		"Temperature",
+	)
+	if err != nil {
+		return err
+	}
+	t.Temperatures = append(
+		t.Temperatures,
+		temperatures...,
+	)
+
+	if subsystem.Fans != "" {
+		fans, err := query.GetMembers[rawFan](
+			client,
+			subsystem.Fans.String(),
+		)
+		if err != nil {
+			return err
+		}
+		for _, fan := range fans {
+			reading, units := fan.SpeedPercent.Reading, "%"
+			if fan.SpeedPercent.SpeedRPM != nil {
+				reading, units = fan.SpeedPercent.SpeedRPM, "RPM"
+			}
+			t.Fans = append(
+				t.Fans,
+				newReading(
+					ch.ID,
+					fan.Name,
+					"Fan",
+					reading,
+					units,
+					thresholds{},
+					fan.Status,
+				),
+			)
+		}
+	}
+
+	for _, redundancy := range subsystem.FanRedundancy {
+		t.Redundancy = append(
+			t.Redundancy,
+			Redundancy{
+				Chassis:   ch.ID,
+				Name:      "Fans",
+				Mode:      redundancy.RedundancyType,
+				MinNeeded: redundancy.MinNeededInGroup,
+				Health:    string(redundancy.Status.Health),
+			},
+		)
+	}
+	return nil
+}
@@ -30,7 +30,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/Cray-HPE/gru/pkg/cmd/cli/chassis/boot"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/chassis/power"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/firmware"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/memory"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/network"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/pcie"
 	"github.com/Cray-HPE/gru/pkg/cmd/cli/proc"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/sensor"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/storage"
 	"github.com/Cray-HPE/gru/pkg/cmd/cli/system"
 )
 
@@ -42,8 +49,17 @@ func NewCommand() *cobra.Command {
 		Long:  `Print pre-defined classes of information from one or more BMCs`,
 	}
 	c.AddCommand(
+		proc.NewShowAcceleratorsCommand(),
 		boot.NewShowCommand(),
+		firmware.NewShowCommand(),
+		memory.NewShowCommand(),
+		network.NewShowCommand(),
+		pcie.NewShowCommand(),
+		power.NewShowCommand(),
 		proc.NewShowCommand(),
+		sensor.NewShowCommand(),
+		storage.NewShowCommand(),
+		sensor.NewShowThermalCommand(),
 		system.NewShowCommand(),
 	)
 	return c
@@ -0,0 +1,173 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package storage
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewShowCommand creates the `storage` subcommand for `show`.
+func NewShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "storage host [...host]",
+		Short: "Storage controllers, drives, and volumes",
+		Long: `Show the Server's storage controllers, its drives with their media type, protocol, capacity, firmware,
+predicted media life left, and health, and its volumes with their RAID type and member drives.
+
+--unhealthy only shows the drives that are unhealthy or predicted to fail.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := query.Async(
+				getStorageInformation,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	c.PersistentFlags().Bool(
+		"unhealthy",
+		false,
+		"Only show drives that are unhealthy or predicted to fail",
+	)
+	return c
+}
+
+func getStorageInformation(host string) interface{} {
+	s := Storage{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		s.Error = err
+		return s
+	}
+	defer c.Logout()
+
+	systems, err := c.Service.Systems()
+	if err != nil || len(systems) < 1 {
+		s.Error = err
+		return s
+	}
+	storage, err := getStorage(systems[0])
+	if err != nil {
+		s.Error = err
+		return s
+	}
+
+	unhealthy := viper.GetBool("unhealthy")
+	for _, st := range storage {
+		drives, err := getDrives(st)
+		if err != nil {
+			s.Error = err
+			return s
+		}
+		for _, d := range drives {
+			if unhealthy && !failing(d) {
+				continue
+			}
+			s.Drives = append(
+				s.Drives,
+				newDrive(
+					st.ID,
+					d,
+				),
+			)
+		}
+		if unhealthy {
+			continue
+		}
+
+		controllers, err := getControllers(st)
+		if err != nil {
+			s.Error = err
+			return s
+		}
+		s.Controllers = append(
+			s.Controllers,
+			controllers...,
+		)
+
+		volumes, err := getVolumes(st)
+		if err != nil {
+			s.Error = err
+			return s
+		}
+		for _, volume := range volumes {
+			members, err := getMemberDrives(volume)
+			if err != nil {
+				s.Error = err
+				return s
+			}
+			var names []string
+			for _, d := range members {
+				names = append(
+					names,
+					d.Name,
+				)
+			}
+			raidType := string(volume.RAIDType)
+			if raidType == "" {
+				raidType = string(volume.VolumeType)
+			}
+			s.Volumes = append(
+				s.Volumes,
+				Volume{
+					Storage:       st.ID,
+					ID:            volume.ID,
+					Name:          volume.Name,
+					RAIDType:      raidType,
+					Capacity:      capacity(int64(volume.CapacityBytes)),
+					CapacityBytes: int64(volume.CapacityBytes),
+					Drives: strings.Join(
+						names,
+						", ",
+					),
+					Health: string(volume.Status.Health),
+				},
+			)
+		}
+	}
+	return s
+}
+
+// failing returns whether a drive is unhealthy or predicted to fail.
+func failing(d *redfish.Drive) bool {
+	return d.FailurePredicted || (d.Status.Health != "" && d.Status.Health != common.OKHealth)
+}
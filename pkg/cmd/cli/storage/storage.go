@@ -0,0 +1,234 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/natural"
+)
+
+// Storage represents the storage controllers, drives, and volumes of every storage subsystem of a host.
+type Storage struct {
+	Controllers []Controller `json:"controllers,omitempty" yaml:"controllers,omitempty"`
+	Drives      []Drive      `json:"drives" yaml:"drives"`
+	Volumes     []Volume     `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+	Error       error        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Controller represents a single storage controller.
+type Controller struct {
+	Storage         string `json:"storage" yaml:"storage"`
+	Name            string `json:"name" yaml:"name"`
+	Model           string `json:"model" yaml:"model"`
+	FirmwareVersion string `json:"firmwareVersion" yaml:"firmware_version"`
+	Health          string `json:"health" yaml:"health"`
+}
+
+// Drive represents a single drive. PredictedMediaLifeLeftPercent is -1 when the drive does not report it.
+type Drive struct {
+	Storage                       string  `json:"storage" yaml:"storage"`
+	ID                            string  `json:"id" yaml:"id"`
+	Name                          string  `json:"name" yaml:"name"`
+	Model                         string  `json:"model" yaml:"model"`
+	MediaType                     string  `json:"mediaType" yaml:"media_type"`
+	Protocol                      string  `json:"protocol" yaml:"protocol"`
+	Capacity                      string  `json:"capacity" yaml:"capacity"`
+	CapacityBytes                 int64   `json:"capacityBytes" yaml:"capacity_bytes"`
+	SerialNumber                  string  `json:"serialNumber" yaml:"serial_number"`
+	FirmwareVersion               string  `json:"firmwareVersion" yaml:"firmware_version"`
+	PredictedMediaLifeLeftPercent float64 `json:"predictedMediaLifeLeftPercent" yaml:"predicted_media_life_left_percent"`
+	FailurePredicted              bool    `json:"failurePredicted" yaml:"failure_predicted"`
+	Health                        string  `json:"health" yaml:"health"`
+	State                         string  `json:"state" yaml:"state"`
+}
+
+// Volume represents a single volume, Drives are the names of its member drives.
+type Volume struct {
+	Storage       string `json:"storage" yaml:"storage"`
+	ID            string `json:"id" yaml:"id"`
+	Name          string `json:"name" yaml:"name"`
+	RAIDType      string `json:"raidType" yaml:"raid_type"`
+	Capacity      string `json:"capacity" yaml:"capacity"`
+	CapacityBytes int64  `json:"capacityBytes" yaml:"capacity_bytes"`
+	Drives        string `json:"drives" yaml:"drives"`
+	Health        string `json:"health" yaml:"health"`
+}
+
+// getStorage returns the storage subsystems of a system, ordered by ID.
+func getStorage(system *redfish.ComputerSystem) ([]*redfish.Storage, error) {
+	storage, err := system.Storage()
+	if err != nil {
+		return nil, err
+	}
+	if len(storage) == 0 {
+		return nil, fmt.Errorf("no storage found")
+	}
+	sort.Slice(
+		storage,
+		func(i, j int) bool {
+			return natural.Less(
+				storage[i].ID,
+				storage[j].ID,
+			)
+		},
+	)
+	return storage, nil
+}
+
+// getDrives returns the drives of a storage subsystem, ordered by ID.
+func getDrives(storage *redfish.Storage) ([]*redfish.Drive, error) {
+	drives, err := storage.Drives()
+	if err != nil {
+		return nil, err
+	}
+	sortDrives(drives)
+	return drives, nil
+}
+
+// getVolumes returns the volumes of a storage subsystem, ordered by ID.
+func getVolumes(storage *redfish.Storage) ([]*redfish.Volume, error) {
+	volumes, err := storage.Volumes()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		volumes,
+		func(i, j int) bool {
+			return natural.Less(
+				volumes[i].ID,
+				volumes[j].ID,
+			)
+		},
+	)
+	return volumes, nil
+}
+
+// getMemberDrives returns the drives of a volume, ordered by ID.
+func getMemberDrives(volume *redfish.Volume) ([]*redfish.Drive, error) {
+	drives, err := volume.Drives()
+	if err != nil {
+		return nil, err
+	}
+	sortDrives(drives)
+	return drives, nil
+}
+
+func sortDrives(drives []*redfish.Drive) {
+	sort.Slice(
+		drives,
+		func(i, j int) bool {
+			return natural.Less(
+				drives[i].ID,
+				drives[j].ID,
+			)
+		},
+	)
+}
+
+// getControllers returns the controllers of a storage subsystem. Older services embed their StorageControllers,
+// newer services link to a Controllers collection.
+func getControllers(storage *redfish.Storage) ([]Controller, error) {
+	controllers := storage.StorageControllers
+	if len(controllers) == 0 {
+		linked, err := storage.Controllers()
+		if err != nil {
+			return nil, err
+		}
+		for _, controller := range linked {
+			controllers = append(
+				controllers,
+				*controller,
+			)
+		}
+	}
+	var found []Controller
+	for _, controller := range controllers {
+		found = append(
+			found,
+			Controller{
+				Storage:         storage.ID,
+				Name:            controller.Name,
+				Model:           controller.Model,
+				FirmwareVersion: controller.FirmwareVersion,
+				Health:          string(controller.Status.Health),
+			},
+		)
+	}
+	return found, nil
+}
+
+// newDrive creates a Drive from a drive of a storage subsystem.
+func newDrive(storage string, d *redfish.Drive) Drive {
+	drive := Drive{
+		Storage:                       storage,
+		ID:                            d.ID,
+		Name:                          d.Name,
+		Model:                         d.Model,
+		MediaType:                     string(d.MediaType),
+		Protocol:                      string(d.Protocol),
+		Capacity:                      capacity(d.CapacityBytes),
+		CapacityBytes:                 d.CapacityBytes,
+		SerialNumber:                  d.SerialNumber,
+		FirmwareVersion:               d.Revision,
+		PredictedMediaLifeLeftPercent: -1,
+		FailurePredicted:              d.FailurePredicted,
+		Health:                        string(d.Status.Health),
+		State:                         string(d.Status.State),
+	}
+	if drive.FirmwareVersion == "" {
+		drive.FirmwareVersion = d.FirmwareVersion
+	}
+	// gofish reports a missing PredictedMediaLifeLeftPercent as 0, the same as a worn out drive.
+	var raw struct {
+		PredictedMediaLifeLeftPercent *float64
+	}
+	if json.Unmarshal(d.RawData, &raw) == nil && raw.PredictedMediaLifeLeftPercent != nil {
+		drive.PredictedMediaLifeLeftPercent = *raw.PredictedMediaLifeLeftPercent
+	}
+	return drive
+}
+
+// capacity formats a number of bytes with decimal (SI) units, as drives are labelled.
+func capacity(bytes int64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB", "PB"}
+	value := float64(bytes)
+	i := 0
+	for value >= 1000 && i < len(units)-1 {
+		value /= 1000
+		i++
+	}
+	return fmt.Sprintf(
+		"%.2f %s",
+		value,
+		units[i],
+	)
+}
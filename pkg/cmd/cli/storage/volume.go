@@ -0,0 +1,904 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package storage
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/glob"
+	"github.com/Cray-HPE/gru/internal/natural"
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/internal/task"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// States of a VolumeChange.
+const (
+	DryRun      = "dry-run"
+	Created     = "created"
+	Deleted     = "deleted"
+	Initialized = "initialized"
+)
+
+// VolumeChange represents the creation, deletion, or initialization of a volume. Drives are the IDs of its member
+// drives, and Task is the task that tracked the change, if any.
+type VolumeChange struct {
+	Storage  string `json:"storage,omitempty" yaml:"storage,omitempty"`
+	Volume   string `json:"volume,omitempty" yaml:"volume,omitempty"`
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"`
+	RAIDType string `json:"raidType,omitempty" yaml:"raid_type,omitempty"`
+	Drives   string `json:"drives,omitempty" yaml:"drives,omitempty"`
+	Task     string `json:"task,omitempty" yaml:"task,omitempty"`
+	State    string `json:"state,omitempty" yaml:"state,omitempty"`
+	Error    error  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// minimumDrives is the minimum number of drives of each RAID type, and the number used unless --count is given.
+var minimumDrives = map[string]int{
+	"RAID0":  1,
+	"RAID1":  2,
+	"RAID1E": 3,
+	"RAID5":  3,
+	"RAID6":  4,
+	"RAID10": 4,
+	"RAID50": 6,
+	"RAID60": 8,
+}
+
+// maximumDrives is the maximum number of drives of the RAID types that have one.
+var maximumDrives = map[string]int{
+	"RAID1": 2,
+}
+
+// NewCommand creates the `storage` subcommand.
+func NewCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:    "storage",
+		Short:  "Storage control",
+		Long:   `Manage a host's storage, see 'gru show storage' for its controllers, drives, and volumes`,
+		Hidden: false,
+	}
+	c.AddCommand(
+		NewVolumeCommand(),
+	)
+	return c
+}
+
+// NewVolumeCommand creates the `volume` subcommand for `storage`.
+func NewVolumeCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "volume",
+		Short: "Volume (RAID) management",
+		Long:  `Create, delete, or initialize volumes`,
+	}
+	c.AddCommand(
+		NewVolumeCreateCommand(),
+		NewVolumeDeleteCommand(),
+		NewVolumeInitializeCommand(),
+	)
+	c.PersistentFlags().String(
+		"storage",
+		"",
+		"ID of the storage subsystem (controller) to use, instead of the one that has the selected drives or volume",
+	)
+	c.PersistentFlags().Bool(
+		"dry-run",
+		false,
+		"Only show the drives or volume that would be used on each host",
+	)
+	c.PersistentFlags().Duration(
+		"timeout",
+		30*time.Minute,
+		"Maximum time to wait for each change's task to complete",
+	)
+	return c
+}
+
+// NewVolumeCreateCommand creates the `create` subcommand for `volume`.
+func NewVolumeCreateCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "create host [...host]",
+		Short: "Create a volume",
+		Long: `Creates a volume from the drives matching --drives. Drives that already belong to a volume, are unhealthy, or
+are predicted to fail are never selected. Each selector is one of:
+  - id:<pattern> or <pattern>   the drive's ID or name
+  - serial:<pattern>            the drive's serial number
+  - model:<pattern>             the drive's model
+  - media:<type>                the drive's media type, e.g. media:SSD
+  - protocol:<protocol>         the drive's protocol, e.g. protocol:NVMe
+Patterns are case-insensitive, * and ? are wildcards. Selectors of the same kind match any of their patterns, a drive
+must match every kind of selector given. Unless --count is given, exactly the RAID type's minimum number of drives
+(e.g. 2 for RAID1, 3 for RAID5) must match. RAID1 always uses 2 drives. For example, the first two SATA SSDs:
+
+  gru storage volume create --raid RAID1 --drives protocol:SATA,media:SSD --count 2 --name boot host`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			raidType := strings.ToUpper(v.GetString("raid"))
+			if _, ok := minimumDrives[raidType]; !ok {
+				cmd.CheckError(
+					fmt.Errorf(
+						"unsupported RAID type: %s",
+						v.GetString("raid"),
+					),
+				)
+			}
+			count := v.GetInt("count")
+			switch {
+			case count < 0:
+				cmd.CheckError(fmt.Errorf("--count must not be negative"))
+			case count > 0 && count < minimumDrives[raidType]:
+				cmd.CheckError(
+					fmt.Errorf(
+						"%s requires at least %d drives, --count is %d",
+						raidType,
+						minimumDrives[raidType],
+						count,
+					),
+				)
+			case count > maximumDrives[raidType] && maximumDrives[raidType] > 0:
+				cmd.CheckError(
+					fmt.Errorf(
+						"%s uses at most %d drives, --count is %d",
+						raidType,
+						maximumDrives[raidType],
+						count,
+					),
+				)
+			}
+			_, err := newDriveSelector(v.GetStringSlice("drives"))
+			cmd.CheckError(err)
+			if initializeType := v.GetString("initialize"); initializeType != "" {
+				_, err = parseInitializeType(initializeType)
+				cmd.CheckError(err)
+			}
+
+			content := set.Async(
+				createVolume,
+				hosts,
+				VolumeChange{
+					Name:     v.GetString("name"),
+					RAIDType: raidType,
+				},
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	c.PersistentFlags().String(
+		"raid",
+		"",
+		"RAID type of the volume, e.g. RAID1",
+	)
+	c.PersistentFlags().StringSlice(
+		"drives",
+		[]string{},
+		"Drive selectors (see above)",
+	)
+	c.PersistentFlags().Int(
+		"count",
+		0,
+		"Use the first count matching drives, required when more drives match than the RAID type's minimum",
+	)
+	c.PersistentFlags().String(
+		"name",
+		"",
+		"Name of the volume",
+	)
+	c.PersistentFlags().String(
+		"initialize",
+		"",
+		"Initialize the volume after it is created: Fast or Slow",
+	)
+	for _, flag := range []string{"raid", "drives", "name"} {
+		cmd.CheckError(c.MarkPersistentFlagRequired(flag))
+	}
+	set.AddFlags(c)
+	return c
+}
+
+// NewVolumeDeleteCommand creates the `delete` subcommand for `volume`.
+func NewVolumeDeleteCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "delete volume host [...host]",
+		Short: "Delete a volume",
+		Long:  `Deletes the volume with the given ID or name, which must match exactly one volume on each host`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) < 1 {
+				cmd.CheckError(fmt.Errorf("no volume given"))
+			}
+			hosts := cli.ParseHosts(args[1:])
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := set.Async(
+				deleteVolume,
+				hosts,
+				args[0],
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	set.AddFlags(c)
+	return c
+}
+
+// NewVolumeInitializeCommand creates the `initialize` subcommand for `volume`.
+func NewVolumeInitializeCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "initialize volume host [...host]",
+		Short: "Initialize (erase) a volume",
+		Long:  `Initializes the volume with the given ID or name, which must match exactly one volume on each host`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) < 1 {
+				cmd.CheckError(fmt.Errorf("no volume given"))
+			}
+			hosts := cli.ParseHosts(args[1:])
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			_, err := parseInitializeType(v.GetString("type"))
+			cmd.CheckError(err)
+
+			content := set.Async(
+				initializeVolume,
+				hosts,
+				args[0],
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	c.PersistentFlags().String(
+		"type",
+		"Fast",
+		"Initialization type: Fast or Slow",
+	)
+	set.AddFlags(c)
+	return c
+}
+
+// initializeTypes are the values of an initialize --type, and of create --initialize.
+var initializeTypes = []string{
+	"Fast",
+	"Slow",
+}
+
+// parseInitializeType returns the canonical name of an initialization type.
+func parseInitializeType(initializeType string) (string, error) {
+	for _, t := range initializeTypes {
+		if strings.EqualFold(t, initializeType) {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf(
+		"invalid initialization type %q, must be one of: %s",
+		initializeType,
+		strings.Join(
+			initializeTypes,
+			", ",
+		),
+	)
+}
+
+// driveSelector matches drives, see NewVolumeCreateCommand.
+type driveSelector map[string][]glob.Pattern
+
+// newDriveSelector parses drive selectors.
+func newDriveSelector(selectors []string) (driveSelector, error) {
+	if len(selectors) == 0 {
+		return nil, fmt.Errorf("no drives given")
+	}
+	ds := make(driveSelector)
+	for _, selector := range selectors {
+		kind, pattern, ok := strings.Cut(selector, ":")
+		if !ok {
+			kind, pattern = "id", selector
+		}
+		switch kind {
+		case "id", "serial", "model", "media", "protocol":
+		default:
+			return nil, fmt.Errorf(
+				"invalid drive selector: %s",
+				selector,
+			)
+		}
+		ds[kind] = append(
+			ds[kind],
+			glob.Compile(pattern),
+		)
+	}
+	return ds, nil
+}
+
+// matches returns whether a drive matches the selector.
+func (ds driveSelector) matches(d *redfish.Drive) bool {
+	values := map[string][]string{
+		"id":       {d.ID, d.Name},
+		"serial":   {d.SerialNumber},
+		"model":    {d.Model},
+		"media":    {string(d.MediaType)},
+		"protocol": {string(d.Protocol)},
+	}
+	for kind, patterns := range ds {
+		matched := false
+		for _, pattern := range patterns {
+			for _, value := range values[kind] {
+				if pattern.MatchString(value) {
+					matched = true
+				}
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// storageVolumes returns the storage subsystems of a host (or only the one given by `--storage`), and their volumes.
+func storageVolumes(c *gofish.APIClient) ([]*redfish.Storage, map[string][]*redfish.Volume, error) {
+	systems, err := c.Service.Systems()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(systems) < 1 {
+		return nil, nil, fmt.Errorf("no systems found")
+	}
+	storage, err := getStorage(systems[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if id := viper.GetString("storage"); id != "" {
+		var selected []*redfish.Storage
+		for _, st := range storage {
+			if st.ID == id {
+				selected = append(
+					selected,
+					st,
+				)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, nil, fmt.Errorf(
+				"storage %s not found",
+				id,
+			)
+		}
+		storage = selected
+	}
+
+	volumes := make(map[string][]*redfish.Volume)
+	for _, st := range storage {
+		volumes[st.ID], err = getVolumes(st)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return storage, volumes, nil
+}
+
+// selectDrives returns the storage subsystem and the available drives in it matching `--drives`.
+func selectDrives(storage []*redfish.Storage, volumes map[string][]*redfish.Volume) (*redfish.Storage, []*redfish.Drive, error) {
+	selector, err := newDriveSelector(viper.GetStringSlice("drives"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var selected []*redfish.Drive
+	var from []*redfish.Storage
+	for _, st := range storage {
+		used := make(map[string]bool)
+		for _, volume := range volumes[st.ID] {
+			members, err := getMemberDrives(volume)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, d := range members {
+				used[d.ODataID] = true
+			}
+		}
+		drives, err := getDrives(st)
+		if err != nil {
+			return nil, nil, err
+		}
+		matched := 0
+		for _, d := range drives {
+			if used[d.ODataID] || failing(d) || !selector.matches(d) {
+				continue
+			}
+			selected = append(
+				selected,
+				d,
+			)
+			matched++
+		}
+		if matched > 0 {
+			from = append(
+				from,
+				st,
+			)
+		}
+	}
+
+	if len(from) == 0 {
+		return nil, nil, fmt.Errorf("no available drives matched, drives that belong to a volume, are unhealthy, or are predicted to fail are excluded")
+	}
+	if len(from) > 1 {
+		var ids []string
+		for _, st := range from {
+			ids = append(
+				ids,
+				st.ID,
+			)
+		}
+		return nil, nil, fmt.Errorf(
+			"the matched drives belong to more than one storage subsystem (%s), use --storage",
+			strings.Join(
+				ids,
+				", ",
+			),
+		)
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		return natural.Less(selected[i].ID, selected[j].ID)
+	})
+	if count := viper.GetInt("count"); count > 0 {
+		if len(selected) < count {
+			return nil, nil, fmt.Errorf(
+				"only %d available drives matched, %d requested",
+				len(selected),
+				count,
+			)
+		}
+		selected = selected[:count]
+	}
+	return from[0], selected, nil
+}
+
+// findVolume returns the storage subsystem and the one volume with the given ID or name.
+func findVolume(storage []*redfish.Storage, volumes map[string][]*redfish.Volume, selector string) (*redfish.Storage, *redfish.Volume, error) {
+	var matchedStorage []*redfish.Storage
+	var matched []*redfish.Volume
+	for _, st := range storage {
+		for _, volume := range volumes[st.ID] {
+			if volume.ID == selector || volume.Name == selector {
+				matchedStorage = append(
+					matchedStorage,
+					st,
+				)
+				matched = append(
+					matched,
+					volume,
+				)
+			}
+		}
+	}
+	if len(matched) != 1 {
+		return nil, nil, fmt.Errorf(
+			"%d volumes matched %s, expected exactly 1",
+			len(matched),
+			selector,
+		)
+	}
+	return matchedStorage[0], matched[0], nil
+}
+
+// wait waits for the task of an accepted request, if any. Returns the task.
+func wait(c *gofish.APIClient, resp *http.Response) (string, error) {
+	return task.Follow(
+		c,
+		resp,
+		viper.GetDuration("timeout"),
+		nil,
+	)
+}
+
+func createVolume(host string, data any) interface{} {
+	vc := data.(VolumeChange)
+	c, err := auth.Connection(host)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	defer c.Logout()
+
+	storage, volumes, err := storageVolumes(c)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	st, drives, err := selectDrives(
+		storage,
+		volumes,
+	)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	vc.Storage = st.ID
+
+	var ids []string
+	var links []map[string]string
+	for _, d := range drives {
+		ids = append(
+			ids,
+			d.ID,
+		)
+		links = append(
+			links,
+			map[string]string{
+				"@odata.id": d.ODataID,
+			},
+		)
+	}
+	vc.Drives = strings.Join(
+		ids,
+		", ",
+	)
+	if minimum := minimumDrives[vc.RAIDType]; len(drives) < minimum {
+		vc.Error = fmt.Errorf(
+			"%s requires at least %d drives, %d matched",
+			vc.RAIDType,
+			minimum,
+			len(drives),
+		)
+		return vc
+	} else if len(drives) > minimum && viper.GetInt("count") == 0 {
+		vc.Error = fmt.Errorf(
+			"%d drives matched, %s uses %d unless --count is given",
+			len(drives),
+			vc.RAIDType,
+			minimum,
+		)
+		return vc
+	}
+	for _, volume := range volumes[st.ID] {
+		if volume.Name == vc.Name {
+			vc.Error = fmt.Errorf(
+				"volume %s already exists",
+				vc.Name,
+			)
+			return vc
+		}
+	}
+	collection, err := volumesLink(
+		c,
+		st,
+	)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	if collection == "" {
+		vc.Error = fmt.Errorf("storage %s does not support volumes", st.ID)
+		return vc
+	}
+	if viper.GetBool("dry-run") {
+		vc.State = DryRun
+		return vc
+	}
+
+	resp, err := c.Post(
+		collection,
+		map[string]interface{}{
+			"Name":     vc.Name,
+			"RAIDType": vc.RAIDType,
+			"Links": map[string]interface{}{
+				"Drives": links,
+			},
+		},
+	)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	defer resp.Body.Close()
+	location := task.Path(resp.Header.Get("Location"))
+	vc.Task, err = wait(
+		c,
+		resp,
+	)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	vc.State = Created
+
+	// The Location of a created volume is the volume, the Location of an accepted request is its task. Without
+	// either the volume is found by its name.
+	uri := location
+	if vc.Task != "" || location == "" {
+		uri, err = volumeByName(
+			st,
+			vc.Name,
+		)
+		if err != nil {
+			vc.Error = err
+			return vc
+		}
+	}
+	vc.Volume = uri[strings.LastIndex(uri, "/")+1:]
+
+	if initializeType := viper.GetString("initialize"); initializeType != "" {
+		initializeTask, err := initialize(
+			c,
+			uri,
+			initializeType,
+		)
+		if initializeTask != "" {
+			vc.Task = initializeTask
+		}
+		if err != nil {
+			vc.Error = err
+			return vc
+		}
+		vc.State = Initialized
+	}
+	return vc
+}
+
+// volumesLink returns the link to the volumes of a storage subsystem, which gofish does not expose.
+func volumesLink(c *gofish.APIClient, st *redfish.Storage) (string, error) {
+	var raw struct {
+		Volumes common.Link
+	}
+	err := query.GetJSON(
+		c,
+		st.ODataID,
+		&raw,
+	)
+	return raw.Volumes.String(), err
+}
+
+// volumeByName returns the link to the volume with the given name.
+func volumeByName(st *redfish.Storage, name string) (string, error) {
+	volumes, err := st.Volumes()
+	if err != nil {
+		return "", err
+	}
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return volume.ODataID, nil
+		}
+	}
+	return "", fmt.Errorf(
+		"volume %s not found after it was created",
+		name,
+	)
+}
+
+// initialize initializes a volume, and waits for its task if any. Returns the task. The action is read from the raw
+// volume, gofish exposes neither its target nor its allowable values.
+func initialize(c *gofish.APIClient, uri string, initializeType string) (string, error) {
+	var raw struct {
+		Actions struct {
+			Initialize struct {
+				Target          string   `json:"target"`
+				AllowableValues []string `json:"InitializeType@Redfish.AllowableValues"`
+			} `json:"#Volume.Initialize"`
+		}
+	}
+	err := query.GetJSON(
+		c,
+		uri,
+		&raw,
+	)
+	if err != nil {
+		return "", err
+	}
+	if raw.Actions.Initialize.Target == "" {
+		return "", fmt.Errorf("the volume does not support Initialize")
+	}
+	initializeType, err = parseInitializeType(initializeType)
+	if err != nil {
+		return "", err
+	}
+	if allowed := raw.Actions.Initialize.AllowableValues; len(allowed) > 0 {
+		found := false
+		for _, a := range allowed {
+			if a == initializeType {
+				found = true
+			}
+		}
+		if !found {
+			return "", fmt.Errorf(
+				"the volume does not allow a %s initialization, it allows: %s",
+				initializeType,
+				strings.Join(
+					allowed,
+					", ",
+				),
+			)
+		}
+	}
+
+	resp, err := c.Post(
+		raw.Actions.Initialize.Target,
+		map[string]interface{}{
+			"InitializeType": initializeType,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	return wait(
+		c,
+		resp,
+	)
+}
+
+func deleteVolume(host string, data any) interface{} {
+	vc := VolumeChange{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	defer c.Logout()
+
+	storage, volumes, err := storageVolumes(c)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	st, volume, err := findVolume(
+		storage,
+		volumes,
+		data.(string),
+	)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	err = describe(
+		&vc,
+		st,
+		volume,
+	)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	if viper.GetBool("dry-run") {
+		vc.State = DryRun
+		return vc
+	}
+
+	resp, err := c.Delete(volume.ODataID)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	defer resp.Body.Close()
+	vc.Task, err = wait(
+		c,
+		resp,
+	)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	vc.State = Deleted
+	return vc
+}
+
+func initializeVolume(host string, data any) interface{} {
+	vc := VolumeChange{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	defer c.Logout()
+
+	storage, volumes, err := storageVolumes(c)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	st, volume, err := findVolume(
+		storage,
+		volumes,
+		data.(string),
+	)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	err = describe(
+		&vc,
+		st,
+		volume,
+	)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	if viper.GetBool("dry-run") {
+		vc.State = DryRun
+		return vc
+	}
+
+	vc.Task, err = initialize(
+		c,
+		volume.ODataID,
+		viper.GetString("type"),
+	)
+	if err != nil {
+		vc.Error = err
+		return vc
+	}
+	vc.State = Initialized
+	return vc
+}
+
+// describe sets the storage subsystem, volume, and member drives of a VolumeChange from an existing volume.
+func describe(vc *VolumeChange, st *redfish.Storage, volume *redfish.Volume) error {
+	vc.Storage = st.ID
+	vc.Volume = volume.ID
+	vc.Name = volume.Name
+	vc.RAIDType = string(volume.RAIDType)
+	members, err := getMemberDrives(volume)
+	if err != nil {
+		return err
+	}
+	var drives []string
+	for _, d := range members {
+		drives = append(
+			drives,
+			d.ID,
+		)
+	}
+	vc.Drives = strings.Join(
+		drives,
+		", ",
+	)
+	return nil
+}
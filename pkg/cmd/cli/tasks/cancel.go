@@ -0,0 +1,107 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/internal/task"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewCancelCommand creates the `cancel` subcommand for `tasks`.
+func NewCancelCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "cancel task host [...host]",
+		Short: "Cancel a task or job",
+		Long:  `Cancel (delete) a task or job that is not done. Not every BMC allows every task or job to be cancelled.`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) < 1 {
+				cmd.CheckError(fmt.Errorf("no task given"))
+			}
+			hosts := cli.ParseHosts(args[1:])
+			content := set.Async(
+				cancelTask,
+				hosts,
+				args[0],
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	set.AddFlags(c)
+	return c
+}
+
+func cancelTask(host string, data any) interface{} {
+	c, err := auth.Connection(host)
+	if err != nil {
+		return Task{Error: err}
+	}
+	defer c.Logout()
+
+	t, err := find(
+		c,
+		c.Service.ODataID,
+		data.(string),
+	)
+	if err != nil {
+		return Task{Error: err}
+	}
+	if task.Done(redfish.TaskState(t.State)) {
+		t.Error = fmt.Errorf(
+			"%s is already %s",
+			t.ID,
+			t.State,
+		)
+		return t
+	}
+
+	_, err = c.Delete(t.URI)
+	if err != nil {
+		t.Error = err
+		return t
+	}
+	// A cancelled task may be removed immediately.
+	s, err := task.Get(
+		c,
+		t.URI,
+	)
+	if err != nil {
+		t.State = string(redfish.CancelledTaskState)
+		return t
+	}
+	return newTask(
+		t.Service,
+		s,
+	)
+}
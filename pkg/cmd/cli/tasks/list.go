@@ -0,0 +1,105 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package tasks
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/internal/task"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// Tasks represents the tasks and jobs of a host.
+type Tasks struct {
+	Tasks []Task `json:"tasks" yaml:"tasks"`
+	Error error  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewListCommand creates the `list` subcommand for `tasks`.
+func NewListCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "list host [...host]",
+		Short: "List tasks and jobs",
+		Long: `List every task and job with its state, percent complete, start and end time, and messages.
+
+--active only lists tasks and jobs that are not done.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := query.Async(
+				listTasks,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	c.PersistentFlags().Bool(
+		"active",
+		false,
+		"Only list tasks and jobs that are not done",
+	)
+	return c
+}
+
+func listTasks(host string) interface{} {
+	t := Tasks{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		t.Error = err
+		return t
+	}
+	defer c.Logout()
+
+	tasks, err := list(
+		c,
+		c.Service.ODataID,
+	)
+	if err != nil {
+		t.Error = err
+		return t
+	}
+	active := viper.GetBool("active")
+	for _, found := range tasks {
+		if active && task.Done(redfish.TaskState(found.State)) {
+			continue
+		}
+		t.Tasks = append(
+			t.Tasks,
+			found,
+		)
+	}
+	return t
+}
@@ -0,0 +1,82 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewShowCommand creates the `show` subcommand for `tasks`.
+func NewShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "show task host [...host]",
+		Short: "Show a task or job",
+		Long:  `Show a task or job with its state, percent complete, start and end time, and messages`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) < 1 {
+				cmd.CheckError(fmt.Errorf("no task given"))
+			}
+			hosts := cli.ParseHosts(args[1:])
+			content := query.Async(
+				func(host string) interface{} {
+					return showTask(
+						host,
+						args[0],
+					)
+				},
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	return c
+}
+
+func showTask(host string, selector string) interface{} {
+	c, err := auth.Connection(host)
+	if err != nil {
+		return Task{Error: err}
+	}
+	defer c.Logout()
+
+	t, err := find(
+		c,
+		c.Service.ODataID,
+		selector,
+	)
+	if err != nil {
+		return Task{Error: err}
+	}
+	return t
+}
@@ -0,0 +1,280 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish/common"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/internal/task"
+)
+
+// Services a Task belongs to.
+const (
+	TaskService = "TaskService"
+	JobService  = "JobService"
+)
+
+// Task represents a task of the TaskService, or a job of the JobService. Messages is a semicolon delimited list of
+// the task's messages.
+type Task struct {
+	ID              string `json:"id" yaml:"id"`
+	Service         string `json:"service" yaml:"service"`
+	Name            string `json:"name" yaml:"name"`
+	State           string `json:"state" yaml:"state"`
+	Health          string `json:"health" yaml:"health"`
+	PercentComplete int    `json:"percentComplete" yaml:"percent_complete"`
+	StartTime       string `json:"startTime" yaml:"start_time"`
+	EndTime         string `json:"endTime" yaml:"end_time"`
+	Messages        string `json:"messages" yaml:"messages"`
+	URI             string `json:"uri" yaml:"uri"`
+	Error           error  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewCommand creates the `tasks` subcommand.
+func NewCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "tasks",
+		Short: "Task and job monitoring",
+		Long: `List, show, wait for, or cancel the tasks (TaskService) and jobs (JobService) of one or more BMCs. A task is
+given by its ID or URI.`,
+	}
+	c.AddCommand(
+		NewListCommand(),
+		NewShowCommand(),
+		NewWaitCommand(),
+		NewCancelCommand(),
+	)
+	return c
+}
+
+// newTask creates a Task from the status of a task.
+func newTask(service string, s task.Status) Task {
+	return Task{
+		ID:              s.ID,
+		Service:         service,
+		Name:            s.Name,
+		State:           string(s.State),
+		Health:          s.Health,
+		PercentComplete: s.PercentComplete,
+		StartTime:       s.StartTime,
+		EndTime:         s.EndTime,
+		Messages: strings.Join(
+			s.Messages,
+			"; ",
+		),
+		URI: s.URI,
+	}
+}
+
+// collections returns the collection of tasks of the TaskService, and of jobs of the JobService, of a service root.
+// Either is empty if the service does not exist.
+func collections(client common.Client, root string) (map[string]string, error) {
+	found := make(map[string]string)
+	var raw struct {
+		TaskService common.Link
+		JobService  common.Link
+	}
+	err := query.GetJSON(
+		client,
+		root,
+		&raw,
+	)
+	if err != nil {
+		return found, err
+	}
+	if raw.TaskService != "" {
+		var service struct {
+			Tasks common.Link
+		}
+		err = query.GetJSON(
+			client,
+			raw.TaskService.String(),
+			&service,
+		)
+		if err != nil {
+			return found, err
+		}
+		found[TaskService] = service.Tasks.String()
+	}
+	if raw.JobService != "" {
+		var service struct {
+			Jobs common.Link
+		}
+		err = query.GetJSON(
+			client,
+			raw.JobService.String(),
+			&service,
+		)
+		if err != nil {
+			return found, err
+		}
+		found[JobService] = service.Jobs.String()
+	}
+	return found, nil
+}
+
+// member is the link of a task or job in its service's collection.
+type member struct {
+	service string
+	link    string
+}
+
+// members returns the links of every task and job of a service root.
+func members(client common.Client, root string) ([]member, error) {
+	found, err := collections(
+		client,
+		root,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var links []member
+	for _, service := range []string{TaskService, JobService} {
+		if found[service] == "" {
+			continue
+		}
+		collection, err := common.GetCollection(
+			client,
+			found[service],
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, link := range collection.ItemLinks {
+			links = append(
+				links,
+				member{
+					service: service,
+					link:    link,
+				},
+			)
+		}
+	}
+	return links, nil
+}
+
+// get returns the task or job of a member. A task or job that cannot be read is returned with its error, and the last
+// segment of its link as ID.
+func get(client common.Client, m member) Task {
+	s, err := task.Get(
+		client,
+		m.link,
+	)
+	if err != nil {
+		return Task{
+			ID:      path.Base(strings.TrimSuffix(m.link, "/")),
+			Service: m.service,
+			URI:     m.link,
+			Error:   err,
+		}
+	}
+	return newTask(
+		m.service,
+		s,
+	)
+}
+
+// list returns every task and job of a service root.
+func list(client common.Client, root string) ([]Task, error) {
+	links, err := members(
+		client,
+		root,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []Task
+	for _, m := range links {
+		tasks = append(
+			tasks,
+			get(
+				client,
+				m,
+			),
+		)
+	}
+	return tasks, nil
+}
+
+// find returns the task or job with the given ID or URI. An ID is matched against the last segment of each task's
+// link first, and only if none matches against the ID every task reports.
+func find(client common.Client, root string, selector string) (Task, error) {
+	if strings.HasPrefix(selector, "/") || strings.Contains(selector, "://") {
+		uri := task.Path(selector)
+		s, err := task.Get(
+			client,
+			uri,
+		)
+		if err != nil {
+			return Task{}, err
+		}
+		service := TaskService
+		if strings.Contains(uri, "/"+JobService+"/") {
+			service = JobService
+		}
+		return newTask(
+			service,
+			s,
+		), nil
+	}
+
+	links, err := members(
+		client,
+		root,
+	)
+	if err != nil {
+		return Task{}, err
+	}
+	for _, m := range links {
+		if path.Base(strings.TrimSuffix(m.link, "/")) == selector {
+			t := get(
+				client,
+				m,
+			)
+			return t, t.Error
+		}
+	}
+	for _, m := range links {
+		t := get(
+			client,
+			m,
+		)
+		if t.Error == nil && t.ID == selector {
+			return t, nil
+		}
+	}
+	return Task{}, fmt.Errorf(
+		"no task or job %s",
+		selector,
+	)
+}
@@ -0,0 +1,142 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/internal/task"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewWaitCommand creates the `wait` subcommand for `tasks`.
+func NewWaitCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "wait task host [...host]",
+		Short: "Wait for a task or job",
+		Long: `Wait until a task or job is done, or until the timeout, printing its progress. Exits non-zero unless it
+completed on every host.`,
+		Run: func(c *cobra.Command, args []string) {
+			if len(args) < 1 {
+				cmd.CheckError(fmt.Errorf("no task given"))
+			}
+			hosts := cli.ParseHosts(args[1:])
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := query.Async(
+				func(host string) interface{} {
+					return waitTask(
+						host,
+						args[0],
+					)
+				},
+				hosts,
+			)
+			cli.PrettyPrint(content)
+
+			var failed []string
+			for host, result := range content {
+				if result.(Task).Error != nil {
+					failed = append(
+						failed,
+						host,
+					)
+				}
+			}
+			if len(failed) > 0 {
+				sort.Strings(failed)
+				cmd.CheckError(
+					fmt.Errorf(
+						"%d host(s) did not complete: %s",
+						len(failed),
+						strings.Join(
+							failed,
+							" ",
+						),
+					),
+				)
+			}
+		},
+	}
+	c.PersistentFlags().Duration(
+		"timeout",
+		30*time.Minute,
+		"Maximum time to wait",
+	)
+	return c
+}
+
+func waitTask(host string, selector string) interface{} {
+	c, err := auth.Connection(host)
+	if err != nil {
+		return Task{Error: err}
+	}
+	defer c.Logout()
+
+	t, err := find(
+		c,
+		c.Service.ODataID,
+		selector,
+	)
+	if err != nil {
+		return Task{Error: err}
+	}
+	s, err := task.Watch(
+		c,
+		t.URI,
+		viper.GetDuration("timeout"),
+		func(s task.Status) {
+			fmt.Fprintf(
+				os.Stderr,
+				"%s: %s %s %d%%\n",
+				host,
+				t.ID,
+				s.State,
+				s.PercentComplete,
+			)
+		},
+	)
+	waited := newTask(
+		t.Service,
+		s,
+	)
+	waited.Error = err
+	return waited
+}
@@ -0,0 +1,163 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package vmedia
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish"
+
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// NewEjectCommand creates the `eject` subcommand for `vmedia`.
+func NewEjectCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "eject host [...host]",
+		Short: "Eject virtual media",
+		Long:  `Eject the media from a virtual media device, or without --device from every device with media inserted.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			content := set.AsyncCall(
+				ejectMedia,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	set.AddFlags(c)
+	return c
+}
+
+func ejectMedia(host string) interface{} {
+	mc := MediaChange{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		mc.Error = err
+		return mc
+	}
+	defer c.Logout()
+
+	media, err := getMedia(c)
+	if err != nil {
+		mc.Error = err
+		return mc
+	}
+	var targets []device
+	if id := viper.GetString("device"); id != "" {
+		m, err := selectMedia(
+			media,
+			id,
+		)
+		if err != nil {
+			mc.Error = err
+			return mc
+		}
+		targets = append(
+			targets,
+			m,
+		)
+	} else {
+		for _, m := range media {
+			if m.Inserted || m.Image != "" {
+				targets = append(
+					targets,
+					m,
+				)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		mc.Error = fmt.Errorf("no virtual media inserted")
+		return mc
+	}
+
+	var ids, images []string
+	for _, m := range targets {
+		err = eject(
+			c,
+			m,
+		)
+		if err != nil {
+			mc.Error = fmt.Errorf(
+				"%s: %v",
+				m.ID,
+				err,
+			)
+			return mc
+		}
+		ids = append(
+			ids,
+			m.ID,
+		)
+		if m.Image != "" {
+			images = append(
+				images,
+				m.Image,
+			)
+		}
+	}
+	mc.Device = strings.Join(
+		ids,
+		", ",
+	)
+	mc.Image = strings.Join(
+		images,
+		", ",
+	)
+	mc.State = Ejected
+	return mc
+}
+
+// eject ejects the media from a device with its EjectMedia action, or by patching the device if it has none.
+func eject(c *gofish.APIClient, m device) error {
+	if m.SupportsMediaEject {
+		return m.EjectMedia()
+	}
+	resp, err := c.Patch(
+		m.ODataID,
+		map[string]any{
+			"Image":    nil,
+			"Inserted": false,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
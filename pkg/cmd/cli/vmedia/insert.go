@@ -0,0 +1,307 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package vmedia
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/internal/serve"
+	"github.com/Cray-HPE/gru/internal/set"
+	"github.com/Cray-HPE/gru/internal/task"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// insertion is the image inserted in every host.
+type insertion struct {
+	image  string
+	server *serve.Server
+}
+
+// NewInsertCommand creates the `insert` subcommand for `vmedia`.
+func NewInsertCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "insert host [...host]",
+		Short: "Insert virtual media",
+		Long: `Insert an image into a virtual media device, the first CD (or DVD) device unless --device is given. The
+image is a URL passed to the BMC, or with --serve, a local image served from a temporary HTTP server that runs until
+gru is interrupted (Ctrl-C). Boot from the image with 'gru chassis boot cd --now'.
+
+Devices that already have media inserted are left alone unless --force is given, ejecting the media first.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+
+			v := viper.GetViper()
+			bindErr := v.BindPFlags(c.Flags())
+			cmd.CheckError(bindErr)
+
+			in := insertion{
+				image: v.GetString("image"),
+			}
+			local := v.GetString("serve")
+			switch {
+			case in.image != "" && local != "":
+				cmd.CheckError(fmt.Errorf("--image and --serve are mutually exclusive"))
+			case in.image == "" && local == "":
+				cmd.CheckError(fmt.Errorf("no image given"))
+			case local != "":
+				server, err := serve.Start(
+					local,
+					v.GetInt("serve-port"),
+				)
+				cmd.CheckError(err)
+				defer server.Close()
+				in.server = server
+			}
+
+			content := set.Async(
+				insertMedia,
+				hosts,
+				in,
+			)
+			cli.PrettyPrint(content)
+
+			if in.server != nil && inserted(content) {
+				fmt.Fprintln(
+					os.Stderr,
+					"Serving",
+					local,
+					"until interrupted (Ctrl-C), eject the media before then",
+				)
+				ctx, stop := signal.NotifyContext(
+					context.Background(),
+					os.Interrupt,
+					syscall.SIGTERM,
+				)
+				defer stop()
+				<-ctx.Done()
+			}
+		},
+	}
+	c.PersistentFlags().String(
+		"image",
+		"",
+		"URL of the image to insert (e.g. http://10.1.1.1/os.iso)",
+	)
+	c.PersistentFlags().String(
+		"serve",
+		"",
+		"Local image to insert, served from a temporary HTTP server",
+	)
+	c.PersistentFlags().Int(
+		"serve-port",
+		0,
+		"Port of the temporary HTTP server, 0 picks a free port",
+	)
+	c.PersistentFlags().Bool(
+		"write-protected",
+		true,
+		"Insert the image write protected",
+	)
+	c.PersistentFlags().Bool(
+		"inserted",
+		true,
+		"Insert the image as present in the device, false only attaches it",
+	)
+	c.PersistentFlags().String(
+		"transfer-method",
+		"",
+		"How the BMC transfers the image; Stream or Upload, the BMC's default if not given",
+	)
+	c.PersistentFlags().Bool(
+		"force",
+		false,
+		"Eject media already inserted in the device",
+	)
+	c.PersistentFlags().Duration(
+		"timeout",
+		5*time.Minute,
+		"Maximum time to wait for the insertion to complete",
+	)
+	set.AddFlags(c)
+	return c
+}
+
+// inserted returns whether the image was inserted in any host.
+func inserted(content map[string]interface{}) bool {
+	for _, v := range content {
+		if mc, ok := v.(MediaChange); ok && mc.Error == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func insertMedia(host string, data any) interface{} {
+	in := data.(insertion)
+	mc := MediaChange{
+		Image: in.image,
+	}
+	c, err := auth.Connection(host)
+	if err != nil {
+		mc.Error = err
+		return mc
+	}
+	defer c.Logout()
+
+	if in.server != nil {
+		mc.Image, err = in.server.URL(host)
+		if err != nil {
+			mc.Error = err
+			return mc
+		}
+	}
+
+	media, err := getMedia(c)
+	if err != nil {
+		mc.Error = err
+		return mc
+	}
+	m, err := selectMedia(
+		media,
+		viper.GetString("device"),
+	)
+	if err != nil {
+		mc.Error = err
+		return mc
+	}
+	mc.Device = m.ID
+	if m.Inserted || m.Image != "" {
+		if !viper.GetBool("force") {
+			mc.Error = fmt.Errorf(
+				"%s already has %s inserted, eject it first or use --force",
+				m.ID,
+				m.Image,
+			)
+			return mc
+		}
+		err = eject(
+			c,
+			m,
+		)
+		if err != nil {
+			mc.Error = err
+			return mc
+		}
+	}
+
+	resp, err := insert(
+		c,
+		m,
+		mc.Image,
+	)
+	if err != nil {
+		mc.Error = err
+		return mc
+	}
+	defer resp.Body.Close()
+
+	mc.Task, err = task.Follow(
+		c,
+		resp,
+		viper.GetDuration("timeout"),
+		nil,
+	)
+	if err != nil {
+		mc.Error = err
+		return mc
+	}
+	mc.State = Inserted
+	return mc
+}
+
+// insert inserts an image with the device's InsertMedia action, or by patching the device if it has none. The action
+// is posted directly since gofish discards the response, and with it the task of an accepted insertion.
+func insert(c *gofish.APIClient, m device, image string) (*http.Response, error) {
+	payload := map[string]any{
+		"Image":          image,
+		"Inserted":       viper.GetBool("inserted"),
+		"WriteProtected": viper.GetBool("write-protected"),
+	}
+	if !m.SupportsMediaInsert {
+		return c.Patch(
+			m.ODataID,
+			payload,
+		)
+	}
+	target, err := insertTarget(
+		c,
+		m,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if method := viper.GetString("transfer-method"); method != "" {
+		payload["TransferMethod"] = method
+	}
+	if protocol := transferProtocol(image); protocol != "" {
+		payload["TransferProtocolType"] = protocol
+	}
+	return c.Post(
+		target,
+		payload,
+	)
+}
+
+// insertTarget returns the target of a device's InsertMedia action, which gofish v0.20 does not expose.
+func insertTarget(c *gofish.APIClient, m device) (string, error) {
+	var raw struct {
+		Actions struct {
+			InsertMedia common.ActionTarget `json:"#VirtualMedia.InsertMedia"`
+		}
+	}
+	err := query.GetJSON(
+		c,
+		m.ODataID,
+		&raw,
+	)
+	return raw.Actions.InsertMedia.Target, err
+}
+
+// transferProtocol returns the TransferProtocolType of an image URL, from its scheme.
+func transferProtocol(image string) string {
+	u, err := url.Parse(image)
+	if err != nil {
+		return ""
+	}
+	return strings.ToUpper(u.Scheme)
+}
@@ -0,0 +1,83 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package vmedia
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/Cray-HPE/gru/internal/query"
+	"github.com/Cray-HPE/gru/pkg/auth"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli"
+)
+
+// VirtualMedia represents the virtual media devices of a host.
+type VirtualMedia struct {
+	Media []Media `json:"media" yaml:"media"`
+	Error error   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// NewShowCommand creates the `show` subcommand for `vmedia`.
+func NewShowCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "show host [...host]",
+		Short: "Show virtual media",
+		Long: `Show every virtual media device with its media types, inserted image, whether it is write protected, and
+how it is connected.`,
+		Run: func(c *cobra.Command, args []string) {
+			hosts := cli.ParseHosts(args)
+			content := query.Async(
+				showMedia,
+				hosts,
+			)
+			cli.PrettyPrint(content)
+		},
+	}
+	return c
+}
+
+func showMedia(host string) interface{} {
+	vm := VirtualMedia{}
+	c, err := auth.Connection(host)
+	if err != nil {
+		vm.Error = err
+		return vm
+	}
+	defer c.Logout()
+
+	media, err := getMedia(c)
+	if err != nil {
+		vm.Error = err
+		return vm
+	}
+	for _, m := range media {
+		vm.Media = append(
+			vm.Media,
+			newMedia(m),
+		)
+	}
+	return vm
+}
@@ -0,0 +1,177 @@
+/*
+
+ MIT License
+
+ (C) Copyright 2023-2024 Hewlett Packard Enterprise Development LP
+
+ Permission is hereby granted, free of charge, to any person obtaining a
+ copy of this software and associated documentation files (the "Software"),
+ to deal in the Software without restriction, including without limitation
+ the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ and/or sell copies of the Software, and to permit persons to whom the
+ Software is furnished to do so, subject to the following conditions:
+
+ The above copyright notice and this permission notice shall be included
+ in all copies or substantial portions of the Software.
+
+ THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL
+ THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR
+ OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+ ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR
+ OTHER DEALINGS IN THE SOFTWARE.
+
+*/
+
+package vmedia
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+
+	"github.com/Cray-HPE/gru/internal/query"
+)
+
+// States of a MediaChange.
+const (
+	Inserted = "inserted"
+	Ejected  = "ejected"
+)
+
+// Media represents a single virtual media device and the image inserted in it, if any. MediaTypes is a comma
+// delimited list of the types of media the device emulates.
+type Media struct {
+	ID             string `json:"id" yaml:"id"`
+	Source         string `json:"source" yaml:"source"`
+	Name           string `json:"name" yaml:"name"`
+	MediaTypes     string `json:"mediaTypes" yaml:"media_types"`
+	Image          string `json:"image" yaml:"image"`
+	Inserted       bool   `json:"inserted" yaml:"inserted"`
+	WriteProtected bool   `json:"writeProtected" yaml:"write_protected"`
+	ConnectedVia   string `json:"connectedVia" yaml:"connected_via"`
+	TransferMethod string `json:"transferMethod" yaml:"transfer_method"`
+	URI            string `json:"uri" yaml:"uri"`
+}
+
+// MediaChange represents the insertion or ejection of virtual media. Device is a comma delimited list of the IDs of
+// the devices changed.
+type MediaChange struct {
+	Device string `json:"device,omitempty" yaml:"device,omitempty"`
+	Image  string `json:"image,omitempty" yaml:"image,omitempty"`
+	Task   string `json:"task,omitempty" yaml:"task,omitempty"`
+	State  string `json:"state,omitempty" yaml:"state,omitempty"`
+	Error  error  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// device is a virtual media device and the source of its owner.
+type device struct {
+	*redfish.VirtualMedia
+	source string
+}
+
+// NewCommand creates the `vmedia` subcommand.
+func NewCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "vmedia",
+		Short: "Virtual media",
+		Long: `Show, insert, or eject the virtual media of one or more BMCs, the virtual media devices of both the
+System and the Manager are used.`,
+	}
+	c.AddCommand(
+		NewShowCommand(),
+		NewInsertCommand(),
+		NewEjectCommand(),
+	)
+	c.PersistentFlags().String(
+		"device",
+		"",
+		"ID of the virtual media device, instead of the first CD (or DVD) device",
+	)
+	return c
+}
+
+// newMedia creates a Media from a virtual media device.
+func newMedia(m device) Media {
+	var types []string
+	for _, t := range m.MediaTypes {
+		types = append(
+			types,
+			string(t),
+		)
+	}
+	return Media{
+		ID:     m.ID,
+		Source: m.source,
+		Name:   m.Name,
+		MediaTypes: strings.Join(
+			types,
+			", ",
+		),
+		Image:          m.Image,
+		Inserted:       m.Inserted,
+		WriteProtected: m.WriteProtected,
+		ConnectedVia:   string(m.ConnectedVia),
+		TransferMethod: string(m.TransferMethod),
+		URI:            m.ODataID,
+	}
+}
+
+// getMedia returns the virtual media devices of the first system and of every manager.
+func getMedia(c *gofish.APIClient) ([]device, error) {
+	owned, err := query.GetOwned(
+		c,
+		(*redfish.ComputerSystem).VirtualMedia,
+		(*redfish.Manager).VirtualMedia,
+		func(m *redfish.VirtualMedia) string {
+			return m.ID
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var media []device
+	seen := make(map[string]bool)
+	for _, o := range owned {
+		if seen[o.Item.ODataID] {
+			continue
+		}
+		seen[o.Item.ODataID] = true
+		media = append(
+			media,
+			device{
+				VirtualMedia: o.Item,
+				source:       o.Source,
+			},
+		)
+	}
+	return media, nil
+}
+
+// selectMedia returns the device with the given ID, or without an ID the first CD (or DVD) device.
+func selectMedia(media []device, id string) (device, error) {
+	for _, m := range media {
+		if id != "" && strings.EqualFold(m.ID, id) {
+			return m, nil
+		}
+	}
+	if id != "" {
+		return device{}, fmt.Errorf(
+			"no virtual media device %s",
+			id,
+		)
+	}
+	for _, m := range media {
+		for _, t := range m.MediaTypes {
+			if t == redfish.CDMediaType || t == redfish.DVDMediaType {
+				return m, nil
+			}
+		}
+	}
+	return device{}, fmt.Errorf("no virtual CD or DVD device")
+}
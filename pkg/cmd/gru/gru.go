@@ -35,8 +35,16 @@ import (
 	"github.com/Cray-HPE/gru/pkg/auth"
 	"github.com/Cray-HPE/gru/pkg/cmd"
 	"github.com/Cray-HPE/gru/pkg/cmd/cli/bios"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/bmc"
 	"github.com/Cray-HPE/gru/pkg/cmd/cli/chassis"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/export"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/firmware"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/logs"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/secureboot"
 	"github.com/Cray-HPE/gru/pkg/cmd/cli/show"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/storage"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/tasks"
+	"github.com/Cray-HPE/gru/pkg/cmd/cli/vmedia"
 	"github.com/Cray-HPE/gru/pkg/version"
 )
 
@@ -103,10 +111,24 @@ the YAML file may provide these per host.
 		false,
 		"Output in YAML",
 	)
+	c.PersistentFlags().BoolP(
+		"table",
+		"t",
+		false,
+		"Output in a table, a row per host (or per item)",
+	)
 	c.AddCommand(
 		bios.NewCommand(),
+		bmc.NewCommand(),
 		chassis.NewCommand(),
+		export.NewCommand(),
+		firmware.NewCommand(),
+		logs.NewCommand(),
+		secureboot.NewCommand(),
 		show.NewCommand(),
+		storage.NewCommand(),
+		tasks.NewCommand(),
+		vmedia.NewCommand(),
 	)
 
 	return c
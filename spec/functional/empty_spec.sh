@@ -49,7 +49,7 @@ End
 Describe "gru --config ${GRU_CONF} chassis"
 Parameters:matrix 
   "boot"
-  "bios" "hdd" "http" "none" "pxe"
+  "bios" "cd" "hdd" "http" "none" "pxe"
 End
 It "$1 $2 (no hosts given)"
   When call ./gru --config "${GRU_CONF}" "chassis" "$1" "$2"
@@ -77,7 +77,7 @@ End
 Describe "gru --config ${GRU_CONF}"
 Parameters:matrix
   "show"
-  "boot" "proc" "system"
+  "accelerators" "boot" "firmware" "memory" "network" "pcie" "power" "proc" "sensors" "storage" "system" "thermal"
 End
 It "$1 $2 (no hosts given)"
   When call ./gru --config "${GRU_CONF}" "$1" "$2"
@@ -85,3 +85,227 @@ It "$1 $2 (no hosts given)"
   The stderr should include 'no hosts given'
 End
 End
+
+# check 'bios password set' with no hosts given
+Describe "gru --config ${GRU_CONF} bios password set"
+It "(no hosts given)"
+  When call ./gru --config "${GRU_CONF}" bios password set
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+
+# passwords read from stdin require hosts as arguments
+It "--password-stdin (no hosts given)"
+  When call ./gru --config "${GRU_CONF}" bios password set --password-stdin
+  The status should equal 1
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'secureboot' and sub-commands with no hosts given
+Describe "gru --config ${GRU_CONF}"
+Parameters:matrix
+  "secureboot"
+  "disable" "enable" "reset-keys" "show"
+End
+It "$1 $2 (no hosts given)"
+  When call ./gru --config "${GRU_CONF}" "$1" "$2"
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'chassis boot order' and sub-commands with no hosts given
+Describe "gru --config ${GRU_CONF} chassis boot"
+Parameters:matrix
+  "order"
+  "set" "show"
+End
+It "$1 $2 (no hosts given)"
+  When call ./gru --config "${GRU_CONF}" "chassis" "boot" "$1" "$2"
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'chassis boot next' with no boot option or hosts given
+Describe "gru --config ${GRU_CONF} chassis boot next"
+It "(no boot option given)"
+  When call ./gru --config "${GRU_CONF}" chassis boot next
+  The status should equal 1
+  The stderr should include 'no boot option given'
+End
+It "Boot0001 (no hosts given)"
+  When call ./gru --config "${GRU_CONF}" chassis boot next Boot0001
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'bmc reset' with no hosts given
+Describe "gru --config ${GRU_CONF} bmc reset"
+It "(no hosts given)"
+  When call ./gru --config "${GRU_CONF}" bmc reset
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'chassis enclosure' and sub-commands with no hosts given
+Describe "gru --config ${GRU_CONF} chassis"
+Parameters:matrix
+  "enclosure"
+  "cycle" "off" "on" "reset"
+End
+It "$1 $2 (no hosts given)"
+  When call ./gru --config "${GRU_CONF}" "chassis" "$1" "$2"
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'chassis power cap' with no watts or hosts given
+Describe "gru --config ${GRU_CONF} chassis power cap"
+It "set (no watts given)"
+  When call ./gru --config "${GRU_CONF}" chassis power cap set
+  The status should equal 1
+  The stderr should include 'no watts given'
+End
+It "set 500 (no hosts given)"
+  When call ./gru --config "${GRU_CONF}" chassis power cap set 500
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+It "clear (no hosts given)"
+  When call ./gru --config "${GRU_CONF}" chassis power cap clear
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'storage volume create' with no hosts given
+Describe "gru --config ${GRU_CONF} storage volume create"
+It "(no hosts given)"
+  When call ./gru --config "${GRU_CONF}" storage volume create --raid RAID1 --drives protocol:SATA --name boot
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'storage volume delete' and 'initialize' with no volume given
+Describe "gru --config ${GRU_CONF} storage volume"
+Parameters
+  "delete"
+  "initialize"
+End
+It "$1 (no volume given)"
+  When call ./gru --config "${GRU_CONF}" storage volume "$1"
+  The status should equal 1
+  The stderr should include 'no volume given'
+End
+End
+
+# check 'storage volume delete' and 'initialize' with no hosts given
+Describe "gru --config ${GRU_CONF} storage volume"
+Parameters
+  "delete"
+  "initialize"
+End
+It "$1 boot (no hosts given)"
+  When call ./gru --config "${GRU_CONF}" storage volume "$1" boot
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'export dhcp' with no hosts given
+Describe "gru --config ${GRU_CONF} export dhcp"
+It "(no hosts given)"
+  When call ./gru --config "${GRU_CONF}" export dhcp --mapping hosts.yml
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'firmware check' with no hosts given
+Describe "gru --config ${GRU_CONF} firmware check"
+It "(no hosts given)"
+  When call ./gru --config "${GRU_CONF}" firmware check --baseline fw.yaml
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'firmware update' with no hosts given
+Describe "gru --config ${GRU_CONF} firmware update"
+It "(no hosts given)"
+  When call ./gru --config "${GRU_CONF}" firmware update --image https://example.com/fw.bin
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'tasks list' with no hosts given
+Describe "gru --config ${GRU_CONF} tasks list"
+It "(no hosts given)"
+  When call ./gru --config "${GRU_CONF}" tasks list
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'tasks show', 'wait', and 'cancel' with no task given
+Describe "gru --config ${GRU_CONF} tasks"
+Parameters
+  "show"
+  "wait"
+  "cancel"
+End
+It "$1 (no task given)"
+  When call ./gru --config "${GRU_CONF}" tasks "$1"
+  The status should equal 1
+  The stderr should include 'no task given'
+End
+End
+
+# check 'tasks show', 'wait', and 'cancel' with no hosts given
+Describe "gru --config ${GRU_CONF} tasks"
+Parameters
+  "show"
+  "wait"
+  "cancel"
+End
+It "$1 JID_1 (no hosts given)"
+  When call ./gru --config "${GRU_CONF}" tasks "$1" JID_1
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'vmedia' with no hosts given
+Describe "gru --config ${GRU_CONF} vmedia"
+Parameters
+  "show"
+  "insert"
+  "eject"
+End
+It "$1 (no hosts given)"
+  When call ./gru --config "${GRU_CONF}" vmedia "$1"
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End
+
+# check 'logs' with no hosts given
+Describe "gru --config ${GRU_CONF} logs"
+Parameters
+  "list"
+  "show"
+  "clear"
+End
+It "$1 (no hosts given)"
+  When call ./gru --config "${GRU_CONF}" logs "$1"
+  The status should equal 1 # no hosts should error
+  The stderr should include 'no hosts given'
+End
+End